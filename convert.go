@@ -0,0 +1,290 @@
+package structil
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	intType     = reflect.TypeOf(int(0))
+	int64Type   = reflect.TypeOf(int64(0))
+	uintType    = reflect.TypeOf(uint(0))
+	uint64Type  = reflect.TypeOf(uint64(0))
+	float64Type = reflect.TypeOf(float64(0))
+	stringType  = reflect.TypeOf("")
+	boolType    = reflect.TypeOf(false)
+	timeType    = reflect.TypeOf(time.Time{})
+)
+
+// AsInt returns the int converted from the original struct field named name.
+// Numeric fields are converted with overflow checks, string fields are parsed and pointer fields are dereferenced.
+// An error is returned if the field does not exist, is nil or can not be converted.
+func (g *Getter) AsInt(name string) (int, error) {
+	i, err := g.asInt64(name, intType)
+	if err != nil {
+		return 0, err
+	}
+
+	if int64(int(i)) != i {
+		return 0, &FieldError{Name: name, Expected: intType, Err: fmt.Errorf("value %d overflows int", i)}
+	}
+
+	return int(i), nil
+}
+
+// AsInt64 returns the int64 converted from the original struct field named name.
+// Numeric fields are converted with overflow checks, string fields are parsed and pointer fields are dereferenced.
+// An error is returned if the field does not exist, is nil or can not be converted.
+func (g *Getter) AsInt64(name string) (int64, error) {
+	return g.asInt64(name, int64Type)
+}
+
+// asInt64 returns the int64 converted from the field named name as well as AsInt64.
+// expected is the type reported by errors.
+func (g *Getter) asInt64(name string, expected reflect.Type) (int64, error) {
+	v, err := g.asValue(name, expected)
+	if err != nil {
+		return 0, err
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u := v.Uint()
+		if u > math.MaxInt64 {
+			return 0, conversionError(name, expected, v, fmt.Errorf("value %d overflows int64", u))
+		}
+		return int64(u), nil
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if f != math.Trunc(f) {
+			return 0, conversionError(name, expected, v, fmt.Errorf("value %v has fractional part", f))
+		}
+		if f < math.MinInt64 || f >= math.MaxInt64 {
+			return 0, conversionError(name, expected, v, fmt.Errorf("value %v overflows int64", f))
+		}
+		return int64(f), nil
+	case reflect.String:
+		i, err := strconv.ParseInt(strings.TrimSpace(v.String()), 10, 64)
+		if err != nil {
+			return 0, conversionError(name, expected, v, err)
+		}
+		return i, nil
+	}
+
+	return 0, newTypeMismatchError(name, expected, v.Type())
+}
+
+// AsUint returns the uint converted from the original struct field named name.
+// Numeric fields are converted with overflow checks, string fields are parsed and pointer fields are dereferenced.
+// An error is returned if the field does not exist, is nil, is negative or can not be converted.
+func (g *Getter) AsUint(name string) (uint, error) {
+	u, err := g.asUint64(name, uintType)
+	if err != nil {
+		return 0, err
+	}
+
+	if uint64(uint(u)) != u {
+		return 0, &FieldError{Name: name, Expected: uintType, Err: fmt.Errorf("value %d overflows uint", u)}
+	}
+
+	return uint(u), nil
+}
+
+// AsUint64 returns the uint64 converted from the original struct field named name.
+// Numeric fields are converted with overflow checks, string fields are parsed and pointer fields are dereferenced.
+// An error is returned if the field does not exist, is nil, is negative or can not be converted.
+func (g *Getter) AsUint64(name string) (uint64, error) {
+	return g.asUint64(name, uint64Type)
+}
+
+// asUint64 returns the uint64 converted from the field named name as well as AsUint64.
+// expected is the type reported by errors.
+func (g *Getter) asUint64(name string, expected reflect.Type) (uint64, error) {
+	v, err := g.asValue(name, expected)
+	if err != nil {
+		return 0, err
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := v.Int()
+		if i < 0 {
+			return 0, conversionError(name, expected, v, fmt.Errorf("value %d overflows uint64", i))
+		}
+		return uint64(i), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if f != math.Trunc(f) {
+			return 0, conversionError(name, expected, v, fmt.Errorf("value %v has fractional part", f))
+		}
+		if f < 0 || f >= math.MaxUint64 {
+			return 0, conversionError(name, expected, v, fmt.Errorf("value %v overflows uint64", f))
+		}
+		return uint64(f), nil
+	case reflect.String:
+		u, err := strconv.ParseUint(strings.TrimSpace(v.String()), 10, 64)
+		if err != nil {
+			return 0, conversionError(name, expected, v, err)
+		}
+		return u, nil
+	}
+
+	return 0, newTypeMismatchError(name, expected, v.Type())
+}
+
+// AsFloat64 returns the float64 converted from the original struct field named name.
+// Numeric fields are converted, string fields are parsed and pointer fields are dereferenced.
+// An error is returned if the field does not exist, is nil or can not be converted.
+func (g *Getter) AsFloat64(name string) (float64, error) {
+	v, err := g.asValue(name, float64Type)
+	if err != nil {
+		return 0, err
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	case reflect.String:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v.String()), 64)
+		if err != nil {
+			return 0, conversionError(name, float64Type, v, err)
+		}
+		return f, nil
+	}
+
+	return 0, newTypeMismatchError(name, float64Type, v.Type())
+}
+
+// AsString returns the string converted from the original struct field named name.
+// []byte, numeric, bool and fmt.Stringer fields are formatted and pointer fields are dereferenced.
+// An error is returned if the field does not exist, is nil or can not be converted.
+func (g *Getter) AsString(name string) (string, error) {
+	v, err := g.asValue(name, stringType)
+	if err != nil {
+		return "", err
+	}
+
+	if v.Kind() == reflect.String {
+		return v.String(), nil
+	}
+
+	if v.CanInterface() {
+		if s, ok := v.Interface().(fmt.Stringer); ok {
+			return s.String(), nil
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 32), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return string(v.Bytes()), nil
+		}
+	}
+
+	return "", newTypeMismatchError(name, stringType, v.Type())
+}
+
+// AsBool returns the bool converted from the original struct field named name.
+// String fields are parsed by strconv.ParseBool, integer fields must be 0 or 1 and pointer fields are dereferenced.
+// An error is returned if the field does not exist, is nil or can not be converted.
+func (g *Getter) AsBool(name string) (bool, error) {
+	v, err := g.asValue(name, boolType)
+	if err != nil {
+		return false, err
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch v.Int() {
+		case 0:
+			return false, nil
+		case 1:
+			return true, nil
+		}
+		return false, conversionError(name, boolType, v, fmt.Errorf("value %d must be 0 or 1", v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		switch v.Uint() {
+		case 0:
+			return false, nil
+		case 1:
+			return true, nil
+		}
+		return false, conversionError(name, boolType, v, fmt.Errorf("value %d must be 0 or 1", v.Uint()))
+	case reflect.String:
+		b, err := strconv.ParseBool(strings.TrimSpace(v.String()))
+		if err != nil {
+			return false, conversionError(name, boolType, v, err)
+		}
+		return b, nil
+	}
+
+	return false, newTypeMismatchError(name, boolType, v.Type())
+}
+
+// AsTime returns the time.Time converted from the original struct field named name.
+// String fields are parsed as RFC 3339 format and pointer fields are dereferenced.
+// An error is returned if the field does not exist, is nil or can not be converted.
+func (g *Getter) AsTime(name string) (time.Time, error) {
+	v, err := g.asValue(name, timeType)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if v.Kind() == reflect.String {
+		t, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(v.String()))
+		if err != nil {
+			return time.Time{}, conversionError(name, timeType, v, err)
+		}
+		return t, nil
+	}
+
+	if v.CanInterface() && v.Type().ConvertibleTo(timeType) && v.Kind() == reflect.Struct {
+		return v.Convert(timeType).Interface().(time.Time), nil
+	}
+
+	return time.Time{}, newTypeMismatchError(name, timeType, v.Type())
+}
+
+// asValue returns the fully indirected value of the field named name for conversions into the expected type.
+func (g *Getter) asValue(name string, expected reflect.Type) (reflect.Value, error) {
+	fc := g.field(name)
+	if !fc.has {
+		return reflect.Value{}, newFieldNotFoundError(name)
+	}
+
+	v := indirect(fc.value)
+	if !v.IsValid() {
+		return reflect.Value{}, newTypeMismatchError(name, expected, nil)
+	}
+
+	return v, nil
+}
+
+// conversionError returns the error that v of the field named name can not be converted into expected type with err.
+func conversionError(name string, expected reflect.Type, v reflect.Value, err error) error {
+	return &FieldError{Name: name, Expected: expected, Actual: v.Type(), Err: err}
+}
@@ -0,0 +1,342 @@
+package structil_test
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	. "github.com/goldeneggg/structil"
+)
+
+type (
+	ConvertTestStruct struct {
+		Int         int
+		Int8        int8
+		Int64       int64
+		Intptr      *int
+		Intptrptr   **int
+		Uint8       uint8
+		Uint64      uint64
+		MaxUint64   uint64
+		Float32     float32
+		Float64     float64
+		FloatFrac   float64
+		FloatHuge   float64
+		NegInt      int
+		String      string
+		NumString   string
+		FloatString string
+		BoolString  string
+		TimeString  string
+		Bytes       []byte
+		Bool        bool
+		Time        time.Time
+		Timeptr     *time.Time
+		MyTime      ConvertTestTime
+		Duration    time.Duration
+		Nilptr      *int
+		Intf        interface{}
+		Slice       []int
+	}
+
+	ConvertTestTime time.Time
+)
+
+var convertTestTime = time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func newConvertTestGetter(t *testing.T) *Getter {
+	i := 25
+	ip := &i
+	tm := convertTestTime
+
+	g, err := NewGetter(&ConvertTestStruct{
+		Int:         25,
+		Int8:        -8,
+		Int64:       math.MaxInt64,
+		Intptr:      ip,
+		Intptrptr:   &ip,
+		Uint8:       1,
+		Uint64:      64,
+		MaxUint64:   math.MaxUint64,
+		Float32:     1.5,
+		Float64:     42,
+		FloatFrac:   1.5,
+		FloatHuge:   1e20,
+		NegInt:      -1,
+		String:      "abc",
+		NumString:   " 25 ",
+		FloatString: "2.5",
+		BoolString:  "true",
+		TimeString:  "2020-01-02T03:04:05Z",
+		Bytes:       []byte("bytes"),
+		Bool:        true,
+		Time:        convertTestTime,
+		Timeptr:     &tm,
+		MyTime:      ConvertTestTime(convertTestTime),
+		Duration:    time.Second,
+		Intf:        int32(32),
+		Slice:       []int{1},
+	})
+	if err != nil {
+		t.Fatalf("NewGetter() unexpected error [%v] occured.", err)
+	}
+
+	return g
+}
+
+type convertTest struct {
+	name    string
+	want    interface{}
+	wantErr bool
+}
+
+func testConvert(t *testing.T, tests []convertTest, fn func(*Getter, string) (interface{}, error)) {
+	t.Helper()
+
+	g := newConvertTestGetter(t)
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fn(g, tt.name)
+			if err == nil {
+				if tt.wantErr {
+					t.Errorf("error did not occur. got: %v", got)
+					return
+				}
+				if got != tt.want {
+					t.Errorf("unexpected result. got: %v, want: %v", got, tt.want)
+				}
+			} else if !tt.wantErr {
+				t.Errorf("unexpected error [%v] occured.", err)
+			}
+		})
+	}
+}
+
+func TestAsInt(t *testing.T) {
+	t.Parallel()
+
+	tests := []convertTest{
+		{name: "Int", want: 25},
+		{name: "Int8", want: -8},
+		{name: "Intptr", want: 25},
+		{name: "Intptrptr", want: 25},
+		{name: "Uint8", want: 1},
+		{name: "Float64", want: 42},
+		{name: "FloatFrac", wantErr: true},
+		{name: "FloatHuge", wantErr: true},
+		{name: "NumString", want: 25},
+		{name: "String", wantErr: true},
+		{name: "FloatString", wantErr: true},
+		{name: "Bool", wantErr: true},
+		{name: "Duration", want: int(time.Second)},
+		{name: "Intf", want: 32},
+		{name: "Nilptr", wantErr: true},
+		{name: "NotExist", wantErr: true},
+	}
+
+	testConvert(t, tests, func(g *Getter, name string) (interface{}, error) {
+		return g.AsInt(name)
+	})
+}
+
+func TestAsInt64(t *testing.T) {
+	t.Parallel()
+
+	tests := []convertTest{
+		{name: "Int64", want: int64(math.MaxInt64)},
+		{name: "Uint64", want: int64(64)},
+		{name: "MaxUint64", wantErr: true},
+		{name: "NumString", want: int64(25)},
+	}
+
+	testConvert(t, tests, func(g *Getter, name string) (interface{}, error) {
+		return g.AsInt64(name)
+	})
+}
+
+func TestAsUint64(t *testing.T) {
+	t.Parallel()
+
+	tests := []convertTest{
+		{name: "Int", want: uint64(25)},
+		{name: "NegInt", wantErr: true},
+		{name: "Int8", wantErr: true},
+		{name: "MaxUint64", want: uint64(math.MaxUint64)},
+		{name: "Float64", want: uint64(42)},
+		{name: "FloatFrac", wantErr: true},
+		{name: "FloatHuge", wantErr: true},
+		{name: "NumString", want: uint64(25)},
+		{name: "String", wantErr: true},
+	}
+
+	testConvert(t, tests, func(g *Getter, name string) (interface{}, error) {
+		return g.AsUint64(name)
+	})
+}
+
+func TestAsUint(t *testing.T) {
+	t.Parallel()
+
+	tests := []convertTest{
+		{name: "Uint8", want: uint(1)},
+		{name: "Intptr", want: uint(25)},
+		{name: "NegInt", wantErr: true},
+	}
+
+	testConvert(t, tests, func(g *Getter, name string) (interface{}, error) {
+		return g.AsUint(name)
+	})
+}
+
+func TestAsFloat64(t *testing.T) {
+	t.Parallel()
+
+	tests := []convertTest{
+		{name: "Int", want: float64(25)},
+		{name: "Uint64", want: float64(64)},
+		{name: "Float32", want: 1.5},
+		{name: "Float64", want: float64(42)},
+		{name: "FloatString", want: 2.5},
+		{name: "String", wantErr: true},
+		{name: "Bool", wantErr: true},
+		{name: "Nilptr", wantErr: true},
+	}
+
+	testConvert(t, tests, func(g *Getter, name string) (interface{}, error) {
+		return g.AsFloat64(name)
+	})
+}
+
+func TestAsString(t *testing.T) {
+	t.Parallel()
+
+	tests := []convertTest{
+		{name: "String", want: "abc"},
+		{name: "Int", want: "25"},
+		{name: "Intptr", want: "25"},
+		{name: "Int8", want: "-8"},
+		{name: "Uint64", want: "64"},
+		{name: "Float32", want: "1.5"},
+		{name: "FloatHuge", want: "1e+20"},
+		{name: "Bool", want: "true"},
+		{name: "Bytes", want: "bytes"},
+		{name: "Duration", want: "1s"},
+		{name: "Time", want: convertTestTime.String()},
+		{name: "Slice", wantErr: true},
+		{name: "Nilptr", wantErr: true},
+	}
+
+	testConvert(t, tests, func(g *Getter, name string) (interface{}, error) {
+		return g.AsString(name)
+	})
+}
+
+func TestAsBool(t *testing.T) {
+	t.Parallel()
+
+	tests := []convertTest{
+		{name: "Bool", want: true},
+		{name: "BoolString", want: true},
+		{name: "Uint8", want: true},
+		{name: "Int", wantErr: true},
+		{name: "String", wantErr: true},
+		{name: "Float64", wantErr: true},
+	}
+
+	testConvert(t, tests, func(g *Getter, name string) (interface{}, error) {
+		return g.AsBool(name)
+	})
+}
+
+func TestAsTime(t *testing.T) {
+	t.Parallel()
+
+	tests := []convertTest{
+		{name: "Time", want: convertTestTime},
+		{name: "Timeptr", want: convertTestTime},
+		{name: "MyTime", want: convertTestTime},
+		{name: "TimeString", want: convertTestTime},
+		{name: "String", wantErr: true},
+		{name: "Int", wantErr: true},
+		{name: "Nilptr", wantErr: true},
+	}
+
+	testConvert(t, tests, func(g *Getter, name string) (interface{}, error) {
+		return g.AsTime(name)
+	})
+}
+
+func TestAsErrors(t *testing.T) {
+	t.Parallel()
+
+	g := newConvertTestGetter(t)
+
+	tests := []struct {
+		name   string
+		fn     func(string) error
+		field  string
+		wantIs error
+	}{
+		{name: "not found", fn: func(n string) error { _, err := g.AsInt(n); return err }, field: "NotExist", wantIs: ErrFieldNotFound},
+		{name: "mismatch", fn: func(n string) error { _, err := g.AsInt(n); return err }, field: "Bool", wantIs: ErrTypeMismatch},
+		{name: "nil", fn: func(n string) error { _, err := g.AsString(n); return err }, field: "Nilptr", wantIs: ErrTypeMismatch},
+		{name: "parse", fn: func(n string) error { _, err := g.AsFloat64(n); return err }, field: "String", wantIs: strconv.ErrSyntax},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.fn(tt.field)
+			if !errors.Is(err, tt.wantIs) {
+				t.Errorf("errors.Is() is false. err: %v, target: %v", err, tt.wantIs)
+			}
+
+			var fe *FieldError
+			if !errors.As(err, &fe) || fe.Name != tt.field {
+				t.Errorf("errors.As() unexpected result. err: %v", err)
+			}
+		})
+	}
+}
+
+func TestAsErrorsExpectedType(t *testing.T) {
+	t.Parallel()
+
+	g := newConvertTestGetter(t)
+
+	tests := []struct {
+		name         string
+		fn           func(string) error
+		field        string
+		wantExpected reflect.Type
+		wantMismatch bool
+	}{
+		{name: "AsInt with nil", fn: func(n string) error { _, err := g.AsInt(n); return err }, field: "Nilptr", wantExpected: reflect.TypeOf(0), wantMismatch: true},
+		{name: "AsInt with mismatch", fn: func(n string) error { _, err := g.AsInt(n); return err }, field: "Bool", wantExpected: reflect.TypeOf(0), wantMismatch: true},
+		{name: "AsInt with parse error", fn: func(n string) error { _, err := g.AsInt(n); return err }, field: "String", wantExpected: reflect.TypeOf(0)},
+		{name: "AsUint with mismatch", fn: func(n string) error { _, err := g.AsUint(n); return err }, field: "Bool", wantExpected: reflect.TypeOf(uint(0)), wantMismatch: true},
+		{name: "AsBool with integer not 0 or 1", fn: func(n string) error { _, err := g.AsBool(n); return err }, field: "Int", wantExpected: reflect.TypeOf(false)},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.fn(tt.field)
+
+			var fe *FieldError
+			if !errors.As(err, &fe) || fe.Expected != tt.wantExpected {
+				t.Errorf("unexpected error: %v, want expected type: %v", err, tt.wantExpected)
+			}
+			if errors.Is(err, ErrTypeMismatch) != tt.wantMismatch {
+				t.Errorf("errors.Is(err, ErrTypeMismatch) unexpected result. err: %v, want: %v", err, tt.wantMismatch)
+			}
+		})
+	}
+}
@@ -34,6 +34,7 @@ const (
 	patternSlice
 	patternPrmtv
 	patternInterface
+	patternType
 )
 
 var (
@@ -408,6 +409,25 @@ func (b *Builder) AddInterfaceWithTag(name string, isPtr bool, tag string) *Buil
 	return b
 }
 
+// AddType returns a Builder that was added a field of typ named by name parameter.
+func (b *Builder) AddType(name string, typ reflect.Type) *Builder {
+	b.AddTypeWithTag(name, typ, "")
+	return b
+}
+
+// AddTypeWithTag returns a Builder that was added a field of typ with tag named by name parameter.
+func (b *Builder) AddTypeWithTag(name string, typ reflect.Type, tag string) *Builder {
+	p := &addParam{
+		name:    name,
+		intfs:   []interface{}{typ},
+		pattern: patternType,
+		isPtr:   false,
+		tag:     tag,
+	}
+	b.add(p)
+	return b
+}
+
 func (b *Builder) add(p *addParam) {
 	defer func() {
 		err := util.RecoverToError(recover())
@@ -456,6 +476,8 @@ func (b *Builder) add(p *addParam) {
 		typeOf = reflect.SliceOf(reflect.TypeOf(p.intfs[0]))
 	case patternInterface:
 		typeOf = reflect.TypeOf(p.intfs[0]).Elem()
+	case patternType:
+		typeOf = p.intfs[0].(reflect.Type)
 	default:
 		typeOf = reflect.TypeOf(p.intfs[0])
 	}
@@ -521,6 +521,33 @@ func TestBuilderAddSliceWithNil(t *testing.T) {
 	}
 }
 
+func TestBuilderAddType(t *testing.T) {
+	t.Parallel()
+
+	ds, err := NewBuilder().
+		AddType("TypeField", reflect.TypeOf(newDynamicTestStructPtr())).
+		AddTypeWithTag("ErrorField", reflect.TypeOf((*error)(nil)).Elem(), `json:"error_field"`).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() unexpected error [%v] occured.", err)
+	}
+
+	sf, ok := ds.FieldByName("TypeField")
+	if !ok || sf.Type != reflect.TypeOf(newDynamicTestStructPtr()) {
+		t.Errorf("unexpected TypeField: %+v", sf)
+	}
+
+	sf, ok = ds.FieldByName("ErrorField")
+	if !ok || sf.Type.Kind() != reflect.Interface || sf.Tag.Get("json") != "error_field" {
+		t.Errorf("unexpected ErrorField: %+v", sf)
+	}
+
+	_, err = NewBuilder().AddType("TypeFieldWithNil", nil).Build()
+	if err == nil {
+		t.Errorf("expect to occur error but does not")
+	}
+}
+
 type buildArgs struct {
 	builder *Builder
 	isPtr   bool
@@ -1,10 +1,12 @@
-package dynamicstruct
+package dynamicstruct_test
 
 import (
 	"encoding/json"
 	"fmt"
 
 	"github.com/goldeneggg/structil"
+
+	. "github.com/goldeneggg/structil/dynamicstruct"
 )
 
 func Example() {
@@ -0,0 +1,236 @@
+package structil
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+var (
+	// ErrFieldNotFound is the error that the struct field does not exist.
+	ErrFieldNotFound = errors.New("field not found")
+
+	// ErrTypeMismatch is the error that the type of the struct field does not match the expected type.
+	ErrTypeMismatch = errors.New("type mismatch")
+)
+
+// errUnexported is the error that the unexported field can not be accessed.
+var errUnexported = errors.New("unexported field can not be accessed")
+
+// FieldError is the error about the struct field.
+// Err is ErrFieldNotFound, ErrTypeMismatch or the other cause, so FieldError can be tested with errors.Is and errors.As.
+type FieldError struct {
+	Name     string       // Field name
+	Expected reflect.Type // Expected type. This is nil if unknown
+	Actual   reflect.Type // Actual type. This is nil if the field does not exist or the value is nil
+	Err      error        // Cause
+}
+
+func newFieldNotFoundError(name string) *FieldError {
+	return &FieldError{Name: name, Err: ErrFieldNotFound}
+}
+
+func newTypeMismatchError(name string, expected reflect.Type, actual reflect.Type) *FieldError {
+	return &FieldError{Name: name, Expected: expected, Actual: actual, Err: ErrTypeMismatch}
+}
+
+// Error returns error string.
+func (e *FieldError) Error() string {
+	switch e.Err {
+	case ErrFieldNotFound:
+		return fmt.Sprintf("field name %s does not exist", e.Name)
+	case ErrTypeMismatch:
+		actual := "nil"
+		if e.Actual != nil {
+			actual = e.Actual.String()
+		}
+		return fmt.Sprintf("field %s: type mismatch. expected: %v, actual: %s", e.Name, e.Expected, actual)
+	default:
+		return fmt.Sprintf("field %s: %v", e.Name, e.Err)
+	}
+}
+
+// Unwrap returns the cause of this error.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// notFoundError is the error that the element of slice, array or map indicated by an accessor does not exist,
+// or the nested struct is nil.
+// It wraps ErrFieldNotFound, so optional fields in them can be tested with errors.Is.
+type notFoundError struct {
+	msg string
+}
+
+func newNotFoundError(format string, a ...interface{}) *notFoundError {
+	return &notFoundError{msg: fmt.Sprintf(format, a...)}
+}
+
+// Error returns error string.
+func (e *notFoundError) Error() string {
+	return e.msg
+}
+
+// Unwrap returns ErrFieldNotFound.
+func (e *notFoundError) Unwrap() error {
+	return ErrFieldNotFound
+}
+
+// MapGetError is the error that holds errors occurred in elements of the field by index.
+type MapGetError struct {
+	Name   string        // Field name
+	Errors map[int]error // Errors by index of elements
+}
+
+// Error returns error string.
+func (e *MapGetError) Error() string {
+	indexes := e.Indexes()
+	es := make([]string, len(indexes))
+	for i, idx := range indexes {
+		es[i] = fmt.Sprintf("[%d] %v", idx, e.Errors[idx])
+	}
+
+	return fmt.Sprintf("field %s: %d errors occurred: %s", e.Name, len(indexes), strings.Join(es, ", "))
+}
+
+// Indexes returns sorted indexes of elements that errors occurred.
+func (e *MapGetError) Indexes() []int {
+	indexes := make([]int, 0, len(e.Errors))
+	for idx := range e.Errors {
+		indexes = append(indexes, idx)
+	}
+	sort.Ints(indexes)
+
+	return indexes
+}
+
+// Is reports whether any error of elements matches target using errors.Is.
+func (e *MapGetError) Is(target error) bool {
+	for _, err := range e.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// As finds the first error of elements in the order of indexes that matches target using errors.As.
+func (e *MapGetError) As(target interface{}) bool {
+	for _, idx := range e.Indexes() {
+		if errors.As(e.Errors[idx], target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SyntaxError is the error of the path expression syntax.
+type SyntaxError struct {
+	Expr   string // Path expression
+	Column int    // Column (1-based byte offset) in Expr where the error is detected
+	Msg    string // Description of the error
+}
+
+// Error returns error string.
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at column %d in %q: %s", e.Column, e.Expr, e.Msg)
+}
+
+// FinderError is the error that holds errors occurred in Finder by path.
+// Path is the key looked up by Finder such as "Company.Group.Name", or the path expression for syntax errors.
+type FinderError struct {
+	Errors map[string][]error // Errors by path
+}
+
+// newFinderError returns a FinderError that has copies of errors in eMap.
+func newFinderError(eMap map[string][]error) *FinderError {
+	errs := make(map[string][]error, len(eMap))
+	for path, es := range eMap {
+		if len(es) > 0 {
+			errs[path] = append([]error(nil), es...)
+		}
+	}
+
+	return &FinderError{Errors: errs}
+}
+
+// Paths returns sorted paths that have errors.
+func (e *FinderError) Paths() []string {
+	paths := make([]string, 0, len(e.Errors))
+	for path, errs := range e.Errors {
+		if len(errs) > 0 {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	return paths
+}
+
+// Error returns error string.
+// Errors are joined by the newline in the order of sorted paths, and in the order of occurrence for each path.
+func (e *FinderError) Error() string {
+	var es []string
+	for _, path := range e.Paths() {
+		for _, err := range e.Errors[path] {
+			es = append(es, err.Error())
+		}
+	}
+
+	return strings.Join(es, "\n")
+}
+
+// Is reports whether any error matches target using errors.Is.
+func (e *FinderError) Is(target error) bool {
+	for _, errs := range e.Errors {
+		for _, err := range errs {
+			if errors.Is(err, target) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// As finds the first error that matches target using errors.As in the order of Error.
+func (e *FinderError) As(target interface{}) bool {
+	for _, path := range e.Paths() {
+		for _, err := range e.Errors[path] {
+			if errors.As(err, target) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// finderErrorEntry is the JSON representation of errors of a path.
+type finderErrorEntry struct {
+	Path   string   `json:"path"`
+	Errors []string `json:"errors"`
+}
+
+// MarshalJSON returns the JSON encoding of this error.
+// e.g. {"errors":[{"path":"Company.Name","errors":["field name Name does not exist"]}]}
+func (e *FinderError) MarshalJSON() ([]byte, error) {
+	paths := e.Paths()
+	entries := make([]finderErrorEntry, len(paths))
+	for i, path := range paths {
+		es := make([]string, len(e.Errors[path]))
+		for j, err := range e.Errors[path] {
+			es[j] = err.Error()
+		}
+		entries[i] = finderErrorEntry{Path: path, Errors: es}
+	}
+
+	return json.Marshal(struct {
+		Errors []finderErrorEntry `json:"errors"`
+	}{Errors: entries})
+}
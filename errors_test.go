@@ -0,0 +1,111 @@
+package structil_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	. "github.com/goldeneggg/structil"
+)
+
+func TestFieldError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		err        *FieldError
+		wantString string
+		wantIs     error
+	}{
+		{
+			name:       "field not found",
+			err:        &FieldError{Name: "A", Err: ErrFieldNotFound},
+			wantString: "field name A does not exist",
+			wantIs:     ErrFieldNotFound,
+		},
+		{
+			name:       "type mismatch",
+			err:        &FieldError{Name: "A", Expected: reflect.TypeOf(0), Actual: reflect.TypeOf(""), Err: ErrTypeMismatch},
+			wantString: "field A: type mismatch. expected: int, actual: string",
+			wantIs:     ErrTypeMismatch,
+		},
+		{
+			name:       "type mismatch with nil value",
+			err:        &FieldError{Name: "A", Expected: reflect.TypeOf(0), Err: ErrTypeMismatch},
+			wantString: "field A: type mismatch. expected: int, actual: nil",
+			wantIs:     ErrTypeMismatch,
+		},
+		{
+			name:       "other cause",
+			err:        &FieldError{Name: "A", Err: strconv.ErrSyntax},
+			wantString: "field A: invalid syntax",
+			wantIs:     strconv.ErrSyntax,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if tt.err.Error() != tt.wantString {
+				t.Errorf("Error() unexpected result. got: %s, want: %s", tt.err.Error(), tt.wantString)
+			}
+			if !errors.Is(tt.err, tt.wantIs) {
+				t.Errorf("errors.Is() is false. err: %v, target: %v", tt.err, tt.wantIs)
+			}
+		})
+	}
+}
+
+func TestSyntaxError(t *testing.T) {
+	t.Parallel()
+
+	err := &SyntaxError{Expr: "A.{B", Column: 3, Msg: "'{' is not closed"}
+	want := `syntax error at column 3 in "A.{B": '{' is not closed`
+	if err.Error() != want {
+		t.Errorf("Error() unexpected result. got: %s, want: %s", err.Error(), want)
+	}
+}
+
+func TestFinderError(t *testing.T) {
+	t.Parallel()
+
+	errB := errors.New("b error")
+	err := &FinderError{Errors: map[string][]error{
+		"B":   {errB, errors.New("b error 2")},
+		"A.C": {&FieldError{Name: "C", Err: ErrFieldNotFound}},
+		"D":   {},
+	}}
+
+	if d := cmp.Diff(err.Paths(), []string{"A.C", "B"}); d != "" {
+		t.Errorf("Paths() unexpected result. (-got +want)\n%s", d)
+	}
+
+	want := "field name C does not exist\nb error\nb error 2"
+	if err.Error() != want {
+		t.Errorf("Error() unexpected result. got: %s, want: %s", err.Error(), want)
+	}
+
+	if !errors.Is(err, errB) || !errors.Is(err, ErrFieldNotFound) || errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("errors.Is() unexpected result. err: %v", err)
+	}
+
+	var fe *FieldError
+	if !errors.As(err, &fe) || fe.Name != "C" {
+		t.Errorf("errors.As() unexpected result. err: %v", err)
+	}
+
+	b, jerr := json.Marshal(err)
+	if jerr != nil {
+		t.Fatalf("json.Marshal() unexpected error [%v] occured.", jerr)
+	}
+	wantJSON := `{"errors":[{"path":"A.C","errors":["field name C does not exist"]},{"path":"B","errors":["b error","b error 2"]}]}`
+	if string(b) != wantJSON {
+		t.Errorf("MarshalJSON() unexpected result. got: %s, want: %s", b, wantJSON)
+	}
+}
@@ -2,6 +2,7 @@ package structil
 
 import (
 	"fmt"
+	"reflect"
 )
 
 func ExampleGetter() {
@@ -112,6 +113,98 @@ func ExampleGetter_MapGet() {
 	// []interface {}{"You worked for 3 years since you joined the company Tiger inc.", "You worked for 4 years since you joined the company Dragon inc."}
 }
 
+func ExampleGetter_WalkRecursive() {
+	type Account struct {
+		User     string
+		Password string
+	}
+
+	type Service struct {
+		Name     string
+		Accounts []*Account
+	}
+
+	i := &Service{
+		Name: "api",
+		Accounts: []*Account{
+			{User: "tony", Password: "secret1"},
+			{User: "steve", Password: "secret2"},
+		},
+	}
+
+	getter, err := NewGetter(i)
+	if err != nil {
+		panic(err)
+	}
+
+	// redact all "Password" fields
+	err = getter.WalkRecursive(func(path string, sf reflect.StructField, v reflect.Value) error {
+		if sf.Name == "Password" && v.CanSet() {
+			v.SetString("***")
+		}
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	for _, a := range i.Accounts {
+		fmt.Printf("%s:%s\n", a.User, a.Password)
+	}
+	// Output:
+	// tony:***
+	// steve:***
+}
+
+func ExampleSetter() {
+	type Company struct {
+		Name    string
+		Address string
+		Period  int
+	}
+
+	type Person struct {
+		Name     string
+		Age      int
+		Nickname *string
+		*Company
+	}
+
+	i := &Person{
+		Name: "Tony",
+		Age:  25,
+	}
+
+	// i must be a struct pointer
+	setter, err := NewSetter(i)
+	if err != nil {
+		panic(err)
+	}
+
+	_ = setter.SetString("Name", "Tony Stark") // set as string
+	_ = setter.SetInt("Age", 26)               // set as int
+	_ = setter.Set("Nickname", "Iron Man")     // pointer field is set with a new pointer
+	_ = setter.Set("Address", "New York")      // nil embedded struct pointer is allocated
+
+	// type mismatch returns an error instead of panic
+	err = setter.Set("Age", "26")
+
+	fmt.Printf(
+		"'Name'=%s\n'Age'=%d\n'Nickname'=%s\n'Address'=%s\nerror=%v",
+		i.Name,
+		i.Age,
+		*i.Nickname,
+		i.Address,
+		err,
+	)
+	// Output:
+	// 'Name'=Tony Stark
+	// 'Age'=26
+	// 'Nickname'=Iron Man
+	// 'Address'=New York
+	// error=field Age: type mismatch. expected: int, actual: string
+}
+
 func ExampleFinder() {
 	type Group struct {
 		Name string
@@ -186,6 +279,89 @@ func ExampleFinder() {
 	// map[string]interface {}{"Company>Address":"New York", "Company>Group>Boss":"Donald", "Company>Group>Name":"YYY Group Holdings", "School":structil.School{Name:"ABC College", GraduatedYear:1995}}
 }
 
+func ExampleFinder_Into_wildcard() {
+	type Company struct {
+		Name    string
+		Address string
+	}
+
+	type Person struct {
+		Name      string
+		Companies []*Company
+		Tags      map[string]string
+	}
+
+	i := &Person{
+		Name: "Tony",
+		Companies: []*Company{
+			{Name: "Tiger inc.", Address: "Tokyo"},
+			{Name: "Dragon inc.", Address: "Osaka"},
+		},
+		Tags: map[string]string{"b": "tag-b", "a": "tag-a"},
+	}
+
+	finder, err := NewFinder(i)
+	if err != nil {
+		panic(err)
+	}
+
+	// Wildcard "*" looks up all elements of slice, array and map.
+	// Map elements are sorted by keys.
+	m, err := finder.
+		Find("Tags[*]").
+		Into("Companies[*]").Find("Name").
+		ToMap()
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("%#v", m)
+	// Output:
+	// map[string]interface {}{"Companies[*].Name":[]interface {}{"Tiger inc.", "Dragon inc."}, "Tags[*]":[]interface {}{"tag-a", "tag-b"}}
+}
+
+func ExampleFinder_Set() {
+	type Group struct {
+		Name string
+		Boss string
+	}
+
+	type Company struct {
+		Name    string
+		Address string
+		*Group
+	}
+
+	type Person struct {
+		Name string
+		*Company
+	}
+
+	i := &Person{
+		Name: "Joe Davis",
+	}
+
+	// i must be a struct pointer to set values
+	finder, err := NewFinder(i)
+	if err != nil {
+		panic(err)
+	}
+
+	// Set sets values using nested field names joined by the separator.
+	// nil struct pointers (e.g. "Company" and "Group") are allocated automatically.
+	err = finder.Set(map[string]interface{}{
+		"Company.Address":    "New York",
+		"Company.Group.Boss": "Donald",
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("Address=%s, Boss=%s", i.Company.Address, i.Company.Group.Boss)
+	// Output:
+	// Address=New York, Boss=Donald
+}
+
 func ExampleFinder_FromKeys_yml() {
 	type Group struct {
 		Name string
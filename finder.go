@@ -1,10 +1,22 @@
 package structil
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"go/token"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v2"
+
+	"github.com/goldeneggg/structil/dynamicstruct"
+	"github.com/goldeneggg/structil/util"
 )
 
 const (
@@ -28,21 +40,66 @@ const (
 // Finder is the struct that builds the nested struct finder.
 type Finder struct {
 	topLevelGetter *Getter
-	gMap           map[string]*Getter
+	gMap           map[string]*node
+	nMap           map[string][]string // names of keys in gMap. keys are not split into names because names may have the separator
 	fMap           map[string][]string
 	eMap           map[string][]error
+	sMap           map[string]*findSpec
+	missing        map[string]bool // keys that Into failed to look up because fields do not exist
 	ck             string
 	sep            string
 }
 
+// findSpec is the specification of a field looked up by Finder.
+// Fields that do not have findSpec are required.
+type findSpec struct {
+	optional    bool // missing field is omitted instead of errors
+	hasDefault  bool
+	def         interface{}
+	alias       string // key in the result of ToMap instead of the path
+	transform   string
+	transformFn TransformFunc
+}
+
+// apply applies the transform to v.
+// If fanOut is true, v is a slice looked up by wildcards and the transform is applied to each element.
+func (spec *findSpec) apply(v interface{}, fanOut bool) (interface{}, error) {
+	if spec == nil || spec.transformFn == nil {
+		return v, nil
+	}
+
+	vs, ok := v.([]interface{})
+	if !fanOut || !ok {
+		return spec.transformFn(v)
+	}
+
+	res := make([]interface{}, len(vs))
+	for i, e := range vs {
+		te, err := spec.transformFn(e)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		res[i] = te
+	}
+
+	return res, nil
+}
+
+// node holds Getters looked up by a key.
+// Multiple Getters are held if the key has wildcards. e.g. `Companies[*]`
+type node struct {
+	getters []*Getter
+	fanOut  bool
+}
+
 // NewFinder returns a concrete Finder that uses and obtains from i.
-// i must be a struct or struct pointer.
+// i must be a struct, struct pointer or map that has string keys.
 func NewFinder(i interface{}) (*Finder, error) {
 	return NewFinderWithSep(i, defaultSep)
 }
 
 // NewFinderWithSep returns a concrete Finder that uses and obtains from i using the separator string.
-// i must be a struct or struct pointer.
+// i must be a struct, struct pointer or map that has string keys.
 func NewFinderWithSep(i interface{}, sep string) (*Finder, error) {
 	g, err := NewGetter(i)
 	if err != nil {
@@ -77,68 +134,183 @@ func (f *Finder) FindTop(names ...string) *Finder {
 }
 
 // Find returns a Finder that fields in struct are looked up and held named names.
+// Each name can have index or key accessors for slice, array and map fields. e.g. `Companies[2]`, `Labels["env"]`.
+// Wildcard "*" is available as an index or key to look up all elements, and as a name to look up all fields.
+// e.g. `Companies[*]`, `Labels[*]`, `*`.
+// Values looked up by wildcards are converted into a slice by ToMap.
 func (f *Finder) Find(names ...string) *Finder {
 	return f.find(f.ck, names...)
 }
 
 func (f *Finder) find(fKey string, names ...string) *Finder {
-	if f.HasError() {
+	if f.blocked() {
 		return f
 	}
 
+	for _, name := range f.fMap[fKey] {
+		delete(f.sMap, f.key(fKey, name))
+	}
 	f.fMap[fKey] = make([]string, len(names))
 	copy(f.fMap[fKey], names)
 
 	return f
 }
 
+// FindOptional returns a Finder that fields in struct are looked up as well as Find, but the fields are optional.
+// Optional fields that do not exist are omitted from results of ToMap instead of errors.
+// If the nested struct looked up by Into does not exist, the error of Into is dropped when all names in it are optional.
+// e.g. Into("Profile").FindOptional("Nickname").
+//
+// Unlike Find, names are added to names already looked up, so required and optional fields can be mixed.
+// e.g. Find("Name").FindOptional("Nickname").
+func (f *Finder) FindOptional(names ...string) *Finder {
+	for _, name := range names {
+		f.findWithSpec(name, &findSpec{optional: true})
+	}
+
+	return f
+}
+
+// FindWithDefault returns a Finder that the field named name is looked up as well as FindOptional.
+// If the field does not exist, def is used as the value of it.
+func (f *Finder) FindWithDefault(name string, def interface{}) *Finder {
+	return f.findWithSpec(name, &findSpec{optional: true, hasDefault: true, def: def})
+}
+
+func (f *Finder) findWithSpec(name string, spec *findSpec) *Finder {
+	if f.blocked() {
+		return f
+	}
+
+	if !containsName(f.fMap[f.ck], name) {
+		f.fMap[f.ck] = append(f.fMap[f.ck], name)
+	}
+	f.sMap[f.key(f.ck, name)] = spec
+
+	return f
+}
+
 // Into returns a Finder that nested struct fields are looked up and held named names.
+// Each name can have index or key accessors as well as Find. e.g. Into("Companies[2]").Find("Address").
+// If names have wildcards, all elements are looked up. e.g. Into("Companies[*]").Find("Address").
 func (f *Finder) Into(names ...string) *Finder {
-	if f.HasError() {
+	if f.blocked() {
 		return f
 	}
 
 	f.ck = topLevelKey
 
-	var nextGetter *Getter
+	var nextNode *node
 	var ok bool
 	var err error
-	var intf interface{}
-	nextKey := ""
+	var nextNames []string
 
-	for _, name := range names {
-		if f.HasError() {
+	for _, name := range normalizeNames(names) {
+		if f.blocked() {
 			break
 		}
 
-		if nextKey == "" {
-			nextKey = name
-		} else {
-			nextKey = nextKey + f.sep + name
-		}
+		nextKey := f.key(f.ck, name)
+		nextNames = append(nextNames[:len(nextNames):len(nextNames)], name)
 		err = nil
 
-		nextGetter, ok = f.gMap[nextKey]
-		if !ok {
-			if f.gMap[f.ck].Has(name) {
-				intf, _ = f.gMap[f.ck].Get(name)
-				nextGetter, err = NewGetter(intf)
-			} else {
-				err = fmt.Errorf("name %s does not exist", name)
-			}
+		nextNode, ok = f.gMap[nextKey]
+		// nested nodes of a missing node are missing as well
+		if !ok && f.gMap[f.ck] != nil {
+			nextNode, err = f.gMap[f.ck].into(name)
 		}
 
 		if err != nil {
-			f.addError(nextKey, fmt.Errorf("Error in name: %s, key: %s. [%v]", name, nextKey, err))
+			f.addError(nextKey, fmt.Errorf("Error in name: %s, key: %s. [%w]", name, nextKey, err))
+			if errors.Is(err, ErrFieldNotFound) {
+				f.missing[nextKey] = true
+			}
 		}
 
-		f.gMap[nextKey] = nextGetter
+		f.gMap[nextKey] = nextNode
+		f.nMap[nextKey] = nextNames
 		f.ck = nextKey
 	}
 
 	return f
 }
 
+// into returns a node that holds Getters of nested structs named name.
+func (nd *node) into(name string) (*node, error) {
+	if !nd.fanOut && !isWildcard(name) {
+		frv, err := nd.getters[0].lookup(name)
+		if err != nil {
+			if err == ErrFieldNotFound {
+				err = newFieldNotFoundError(name)
+			}
+			return nil, err
+		}
+		// nil nested structs are missing as well as fields that do not exist
+		if !frv.IsValid() {
+			return nil, newNotFoundError("%s is nil", name)
+		}
+
+		g, err := nd.getters[0].newChild(frv)
+		if err != nil {
+			return nil, err
+		}
+
+		return &node{getters: []*Getter{g}}, nil
+	}
+
+	next := &node{getters: make([]*Getter, 0, len(nd.getters)), fanOut: true}
+	for _, g := range nd.getters {
+		frvs, err := g.lookupAll(name)
+		if err != nil {
+			if err == ErrFieldNotFound {
+				err = newFieldNotFoundError(name)
+			}
+			return nil, err
+		}
+
+		for _, frv := range frvs {
+			// nil elements do not match
+			if !frv.IsValid() {
+				continue
+			}
+
+			eg, err := g.newChild(frv)
+			if err != nil {
+				return nil, err
+			}
+			next.getters = append(next.getters, eg)
+		}
+	}
+
+	return next, nil
+}
+
+// values returns the interfaces of fields named name in Getters.
+// If this node fans out or name has wildcards, the interfaces are converted into a slice.
+func (nd *node) values(name string) (interface{}, error) {
+	if !nd.fanOut && !isWildcard(name) {
+		frv, err := nd.getters[0].lookup(name)
+		if err != nil {
+			return nil, err
+		}
+		return util.ToI(frv), nil
+	}
+
+	res := make([]interface{}, 0, len(nd.getters))
+	for _, g := range nd.getters {
+		frvs, err := g.lookupAll(name)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, frv := range frvs {
+			res = append(res, util.ToI(frv))
+		}
+	}
+
+	return res, nil
+}
+
 func (f *Finder) addError(key string, err error) *Finder {
 	if _, ok := f.eMap[key]; !ok {
 		f.eMap[key] = make([]error, 0, 3)
@@ -148,33 +320,122 @@ func (f *Finder) addError(key string, err error) *Finder {
 	return f
 }
 
-// FromKeys returns a Finder that looked up by FinderKeys generated from configuration file.
-func (f *Finder) FromKeys(fks *FinderKeys) *Finder {
-	var into, find string
-	var ok bool
-	m := make(map[string][]string)
+// key returns the key of the field named name in the node of kg.
+// The separator in name is escaped, so the key does not collide with keys of nested names.
+func (f *Finder) key(kg string, name string) string {
+	name = escapeName(name, f.sep)
+	if kg == topLevelKey {
+		return name
+	}
 
-	for i := 0; i < fks.Len(); i++ {
-		if f.HasError() {
-			return f
+	return kg + f.sep + name
+}
+
+// pathKey returns the key of the node looked up by names.
+func (f *Finder) pathKey(names []string) string {
+	key := topLevelKey
+	for _, name := range names {
+		key = f.key(key, name)
+	}
+
+	return key
+}
+
+// blocked tests whether this Finder has errors other than errors of missing nested structs.
+// Errors of missing nested structs may be dropped later if all names in them are optional.
+func (f *Finder) blocked() bool {
+	for key, errs := range f.eMap {
+		if len(errs) > 0 && !f.missing[key] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolveMissing drops errors of missing nested structs that all names in them are optional.
+func (f *Finder) resolveMissing() {
+MISSING:
+	for mk := range f.missing {
+		optional := false
+		for kg, names := range f.fMap {
+			if kg != mk && !strings.HasPrefix(kg, mk+f.sep) {
+				continue
+			}
+
+			for _, name := range names {
+				if !f.optional(f.key(kg, name)) {
+					continue MISSING
+				}
+				optional = true
+			}
 		}
 
-		into, find = fks.intoAndFindNames(i)
-		if _, ok = m[into]; !ok {
-			m[into] = make([]string, 0, 10)
+		if optional {
+			delete(f.eMap, mk)
 		}
-		m[into] = append(m[into], find)
+	}
+}
+
+// optional tests whether the field of key is optional.
+func (f *Finder) optional(key string) bool {
+	spec, ok := f.sMap[key]
+	return ok && spec.optional
+}
+
+// outNames returns the names of the field named name in the node of kg in the result of ToMap.
+// If the field has an alias, the alias is split by the separator instead.
+func (f *Finder) outNames(kg string, name string) []string {
+	if spec, ok := f.sMap[f.key(kg, name)]; ok && spec.alias != "" {
+		return splitPath(spec.alias, f.sep)
 	}
 
-	var is []string
+	names := f.nMap[kg]
+	return append(names[:len(names):len(names)], name)
+}
 
-	for k, v := range m {
-		if k == topLevelKey {
-			f.FindTop(v...)
-		} else {
-			is = strings.Split(k, defaultSep)
-			f.Into(is...).Find(v...)
+// outKey returns the key in the result of ToMap for the field named name in the node of kg.
+// Names are joined by the separator without escapes, and aliases are used as they are.
+func (f *Finder) outKey(kg string, name string) string {
+	return strings.Join(f.outNames(kg, name), f.sep)
+}
+
+// checkOutKeys holds errors for keys in the result of ToMap that are duplicated
+// by aliases or by names that have the separator.
+func (f *Finder) checkOutKeys() {
+	var keys []string
+	outs := map[string]string{}
+	for kg, names := range f.fMap {
+		for _, name := range names {
+			key := f.key(kg, name)
+			keys = append(keys, key)
+			outs[key] = f.outKey(kg, name)
+		}
+	}
+	sort.Strings(keys)
+
+	seen := make(map[string]string, len(keys))
+	for _, key := range keys {
+		out := outs[key]
+		if prev, ok := seen[out]; ok {
+			f.addError(key, fmt.Errorf("Error in key: %s. [output key %s is duplicated with key %s]", key, out, prev))
+			continue
 		}
+		seen[out] = key
+	}
+}
+
+// FromKeys returns a Finder that looked up by FinderKeys generated from configuration file.
+// Each key is parsed as a path expression joined by "." as well as Query.
+// Policies of keys for missing fields (optional or default) are applied as well as FindOptional and FindWithDefault.
+// Keys that have aliases are output as the aliases by ToMap, and values of keys that have transforms are transformed.
+func (f *Finder) FromKeys(fks *FinderKeys) *Finder {
+	for _, key := range fks.keys {
+		if f.blocked() {
+			return f
+		}
+
+		f.query(key, defaultSep, fks.specs[key])
 	}
 
 	return f
@@ -183,38 +444,562 @@ func (f *Finder) FromKeys(fks *FinderKeys) *Finder {
 // ToMap returns a map converted from struct.
 // Map keys are lookup field names by "Into" method and "Find".
 // Map values are lookup field values by "Into" method and "Find".
+// If keys have aliases by FinderKeys, the aliases are used as map keys instead.
+// Names are joined by the separator without escapes, so an error is held if map keys are duplicated.
+// e.g. Query(`a\.b.c`) and Query("a.b.c").
 func (f *Finder) ToMap() (map[string]interface{}, error) {
+	f.resolveMissing()
+	if f.HasError() {
+		return nil, f.Err()
+	}
+
+	f.checkOutKeys()
 	if f.HasError() {
-		return nil, f
+		return nil, f.Err()
 	}
 
 	res := map[string]interface{}{}
-	var key string
 
-	for kg, getter := range f.gMap {
+	for kg, nd := range f.gMap {
 		for _, name := range f.fMap[kg] {
-			if kg == topLevelKey {
-				key = name
-			} else {
-				key = kg + f.sep + name
+			key := f.key(kg, name)
+			spec := f.sMap[key]
+
+			var v interface{}
+			err := ErrFieldNotFound
+			if nd != nil {
+				v, err = nd.values(name)
+			}
+
+			if errors.Is(err, ErrFieldNotFound) && spec != nil && spec.optional {
+				if spec.hasDefault {
+					res[f.outKey(kg, name)] = spec.def
+				}
+				continue
+			}
+
+			if err != nil {
+				if err == ErrFieldNotFound {
+					err = newFieldNotFoundError(name)
+				}
+				f.addError(key, err)
+				continue
+			}
+
+			v, err = spec.apply(v, nd.fanOut || isWildcard(name))
+			if err != nil {
+				f.addError(key, fmt.Errorf("Error in key: %s. [transform %s: %w]", key, spec.transform, err))
+				continue
+			}
+
+			res[f.outKey(kg, name)] = v
+		}
+	}
+
+	if f.HasError() {
+		return nil, f.Err()
+	}
+
+	return res, nil
+}
+
+// ToNestedMap returns a nested map converted from struct.
+// Unlike ToMap, values looked up by "Into" names are held in nested maps keyed by each name.
+// e.g. Into("Company", "Group").Find("Name") gives {"Company": {"Group": {"Name": ...}}}.
+// Names that have accessors are used as keys as they are. e.g. "Companies[*]".
+// The result can be passed to json.Marshal and DynamicStruct.DecodeMap.
+//
+// An error is held if a name is looked up both as a value and as a nested map.
+// e.g. Find("Company") and Into("Company").Find("Name").
+func (f *Finder) ToNestedMap() (map[string]interface{}, error) {
+	flat, err := f.ToMap()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(flat))
+	kNames := make(map[string][]string, len(flat))
+	for kg, names := range f.fMap {
+		for _, name := range names {
+			key := f.outKey(kg, name)
+			if _, ok := flat[key]; !ok {
+				continue
 			}
+			keys = append(keys, key)
+			kNames[key] = f.outNames(kg, name)
+		}
+	}
+	sort.Strings(keys)
 
-			if !getter.Has(name) {
-				f.addError(key, fmt.Errorf("field name %s does not exist", name))
+	res := map[string]interface{}{}
+	leaves := map[string]bool{}
+	branches := map[string]bool{}
+
+	for _, key := range keys {
+		names := kNames[key]
+
+		m := res
+		conflict := ""
+		for i, name := range names[:len(names)-1] {
+			prefix := f.pathKey(names[:i+1])
+			if leaves[prefix] {
+				conflict = strings.Join(names[:i+1], f.sep)
 				break
 			}
+			branches[prefix] = true
+
+			nm, ok := m[name].(map[string]interface{})
+			if !ok {
+				nm = map[string]interface{}{}
+				m[name] = nm
+			}
+			m = nm
+		}
+		pk := f.pathKey(names)
+		if conflict == "" && branches[pk] {
+			conflict = key
+		}
 
-			res[key], _ = getter.Get(name)
+		if conflict != "" {
+			f.addError(key, fmt.Errorf("Error in key: %s. [name %s is looked up both as a value and as a nested map]", key, conflict))
+			continue
 		}
+
+		leaves[pk] = true
+		m[names[len(names)-1]] = flat[key]
 	}
 
 	if f.HasError() {
-		return nil, f
+		return nil, f.Err()
 	}
 
 	return res, nil
 }
 
+// projection is a field of the struct built by ToStruct.
+type projection struct {
+	sf       reflect.StructField
+	value    reflect.Value
+	children map[string]*projection // nested fields looked up by "Into" names. This is nil for found fields
+}
+
+// ToStruct returns a pointer to the instance of a DynamicStruct that fields looked up by "Into" method and "Find" are set into,
+// and the DynamicStruct.
+// Fields have the same names, types and tags as the original struct fields,
+// and fields looked up by "Into" names are nested structs as well as ToNestedMap.
+//
+// Names that have accessors or wildcards are not supported.
+// If the Finder wraps a map, map keys must be exported Go identifiers.
+// Optional fields that do not exist are omitted, and default values are not used because the types of the fields are unknown.
+// Aliases and transforms of FinderKeys are not used either.
+func (f *Finder) ToStruct() (interface{}, dynamicstruct.DynamicStruct, error) {
+	f.resolveMissing()
+	if f.HasError() {
+		return nil, nil, f.Err()
+	}
+
+	kgs := make([]string, 0, len(f.gMap))
+	for kg := range f.gMap {
+		kgs = append(kgs, kg)
+	}
+	sort.Strings(kgs)
+
+	root := &projection{children: map[string]*projection{}}
+	for _, kg := range kgs {
+		for _, name := range f.fMap[kg] {
+			key := f.key(kg, name)
+
+			// optional fields that do not exist are omitted
+			if f.optional(key) && f.gMap[kg].lacks(name) {
+				continue
+			}
+
+			if err := f.project(root, kg, name); err != nil {
+				f.addError(key, fmt.Errorf("Error in name: %s, key: %s. [%w]", name, key, err))
+			}
+		}
+	}
+
+	if f.HasError() {
+		return nil, nil, f.Err()
+	}
+
+	ds, err := root.build(true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	i := ds.NewInterface()
+	root.fill(reflect.ValueOf(i).Elem())
+
+	return i, ds, nil
+}
+
+// project adds the field named name in the node of kg into root.
+func (f *Finder) project(root *projection, kg string, name string) error {
+	names := f.nMap[kg]
+
+	p := root
+	parent := topLevelKey
+	for _, n := range names {
+		sf, _, err := f.gMap[parent].projectField(n)
+		if err != nil {
+			return err
+		}
+
+		c, ok := p.children[sf.Name]
+		if !ok {
+			c = &projection{sf: sf, children: map[string]*projection{}}
+			p.children[sf.Name] = c
+		}
+		if c.children == nil {
+			return fmt.Errorf("name %s is looked up both as a value and as a nested struct", n)
+		}
+		p = c
+
+		parent = f.key(parent, n)
+	}
+
+	sf, v, err := f.gMap[kg].projectField(name)
+	if err != nil {
+		return err
+	}
+	if _, ok := p.children[sf.Name]; ok {
+		return fmt.Errorf("name %s is looked up both as a value and as a nested struct", name)
+	}
+	p.children[sf.Name] = &projection{sf: sf, value: v}
+
+	return nil
+}
+
+// lacks tests whether the field named name does not exist in this node.
+// A missing node lacks all fields.
+func (nd *node) lacks(name string) bool {
+	if nd == nil {
+		return true
+	}
+
+	_, err := nd.values(name)
+	return errors.Is(err, ErrFieldNotFound)
+}
+
+// projectField returns the struct field for ToStruct and the value of the field named name in this node.
+// A missing node does not have any fields.
+func (nd *node) projectField(name string) (reflect.StructField, reflect.Value, error) {
+	if nd == nil {
+		return reflect.StructField{}, reflect.Value{}, newFieldNotFoundError(name)
+	}
+	if nd.fanOut || isWildcard(name) || hasAccessor(name) {
+		return reflect.StructField{}, reflect.Value{}, fmt.Errorf("name %s has accessors or wildcards", name)
+	}
+
+	g := nd.getters[0]
+	fc := g.field(name)
+	if !fc.has {
+		return reflect.StructField{}, reflect.Value{}, newFieldNotFoundError(name)
+	}
+
+	sf := reflect.StructField{Name: name, Type: fc.typ}
+	if g.ti != nil {
+		fsf := g.ti.fields[name].sf
+		sf = reflect.StructField{Name: fsf.Name, Type: fsf.Type, Tag: fsf.Tag}
+	}
+
+	if !token.IsIdentifier(sf.Name) || !token.IsExported(sf.Name) || (fc.raw.IsValid() && !fc.raw.CanInterface()) {
+		return reflect.StructField{}, reflect.Value{}, &FieldError{Name: name, Err: errUnexported}
+	}
+
+	return sf, fc.raw, nil
+}
+
+// build returns a DynamicStruct that has fields of p.
+func (p *projection) build(isPtr bool) (dynamicstruct.DynamicStruct, error) {
+	b := dynamicstruct.NewBuilder()
+	for name, c := range p.children {
+		typ := c.sf.Type
+		if c.children != nil {
+			cds, err := c.build(false)
+			if err != nil {
+				return nil, err
+			}
+			typ = reflect.TypeOf(cds.NewInterface())
+		}
+		b.AddTypeWithTag(name, typ, string(c.sf.Tag))
+	}
+
+	if isPtr {
+		return b.Build()
+	}
+	return b.BuildNonPtr()
+}
+
+// fill sets values of p into struct rv.
+func (p *projection) fill(rv reflect.Value) {
+	for name, c := range p.children {
+		frv := rv.FieldByName(name)
+		if c.children != nil {
+			c.fill(frv)
+		} else if c.value.IsValid() {
+			frv.Set(c.value)
+		}
+	}
+}
+
+// Set sets values into the struct fields looked up by keys of m.
+// Map keys are nested field names joined by the separator. e.g. "Company.Group.Boss".
+// Nil struct pointers on the way to the target field are allocated.
+// Finder must be created from a struct pointer (or map) to set values.
+// Names can have index or key accessors as well as Find. e.g. `Companies[2].Name`, `Labels["env"]`.
+// Map entries are set by their keys, and structs in maps must be pointers.
+// Errors for each key are held in this Finder like as other methods.
+func (f *Finder) Set(m map[string]interface{}) error {
+	if f.HasError() {
+		return f.Err()
+	}
+
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	errs := map[string]error{}
+	for _, key := range keys {
+		if err := f.set(key, m[key]); err != nil {
+			errs[key] = err
+		}
+	}
+
+	// Getters held in this Finder may refer to old values
+	f.refresh()
+
+	for _, key := range keys {
+		if err, ok := errs[key]; ok {
+			f.addError(key, err)
+		}
+	}
+
+	if f.HasError() {
+		return f.Err()
+	}
+
+	return nil
+}
+
+// SetPath sets v into the struct field looked up by path.
+// path is nested field names joined by the separator. e.g. "Company.Group.Boss".
+func (f *Finder) SetPath(path string, v interface{}) error {
+	return f.Set(map[string]interface{}{path: v})
+}
+
+func (f *Finder) set(key string, v interface{}) error {
+	rv := f.topLevelGetter.rv
+	names := splitPath(key, f.sep)
+
+	for i, name := range names {
+		seg, err := parseSegment(name)
+		if err != nil {
+			return fmt.Errorf("Error in name: %s, key: %s. [%w]", name, key, err)
+		}
+		last := i == len(names)-1
+
+		var frv reflect.Value
+		switch {
+		case rv.Kind() == reflect.Map && len(seg.accessors) == 0:
+			next, err := setMapEntry(rv, seg, last, v)
+			if err != nil {
+				return fmt.Errorf("Error in name: %s, key: %s. [%w]", name, key, err)
+			}
+			rv = next
+			continue
+		case rv.Kind() == reflect.Map:
+			frv, err = mapEntry(rv, seg.name)
+			if err != nil {
+				return fmt.Errorf("Error in name: %s, key: %s. [%w]", name, key, err)
+			}
+		default:
+			if !rv.CanAddr() {
+				return fmt.Errorf("Error in name: %s, key: %s. [struct is not addressable. Finder must be created from a struct pointer]", name, key)
+			}
+
+			fi, ok := typeInfoOf(rv.Type(), f.topLevelGetter.opt.Tag).fields[seg.name]
+			if !ok {
+				return fmt.Errorf("Error in name: %s, key: %s. [%w]", name, key, newFieldNotFoundError(seg.name))
+			}
+
+			frv = fieldByIndex(rv, fi.index, true)
+			if !frv.IsValid() {
+				return fmt.Errorf("Error in name: %s, key: %s. [name %s is not reachable]", name, key, seg.name)
+			}
+			if f.topLevelGetter.opt.Unexported {
+				frv = unlock(frv)
+			}
+		}
+
+		at := seg.name
+		for j, a := range seg.accessors {
+			// the element of map is set by the key
+			if mv := indirect(frv); last && j == len(seg.accessors)-1 && mv.Kind() == reflect.Map {
+				if err := setMapIndex(mv, a, at, name, v); err != nil {
+					return fmt.Errorf("Error in name: %s, key: %s. [%w]", name, key, err)
+				}
+				return nil
+			}
+
+			frv, err = settableAccess(frv, a, at)
+			if err != nil {
+				return fmt.Errorf("Error in name: %s, key: %s. [%w]", name, key, err)
+			}
+			at += a.String()
+		}
+
+		if last {
+			if err := assign(frv, name, v); err != nil {
+				return fmt.Errorf("Error in name: %s, key: %s. [%w]", name, key, err)
+			}
+			break
+		}
+
+		switch frv.Kind() {
+		case reflect.Ptr:
+			if frv.IsNil() {
+				if !frv.CanSet() {
+					return fmt.Errorf("Error in name: %s, key: %s. [name %s is nil and not settable]", name, key, name)
+				}
+				frv.Set(reflect.New(frv.Type().Elem()))
+			}
+			frv = frv.Elem()
+		case reflect.Map:
+			if frv.IsNil() {
+				if !frv.CanSet() {
+					return fmt.Errorf("Error in name: %s, key: %s. [name %s is nil and not settable]", name, key, name)
+				}
+				frv.Set(reflect.MakeMap(frv.Type()))
+			}
+		case reflect.Interface:
+			frv = indirect(frv)
+		}
+
+		if frv.Kind() != reflect.Struct && frv.Kind() != reflect.Map {
+			return fmt.Errorf("Error in name: %s, key: %s. [name %s is not struct: %v]", name, key, name, frv.Kind())
+		}
+
+		rv = frv
+	}
+
+	return nil
+}
+
+// setMapEntry sets v into map rv with the key of seg if last is true.
+// Otherwise this returns the struct or map value of the key to set into it.
+func setMapEntry(rv reflect.Value, seg *segment, last bool, v interface{}) (reflect.Value, error) {
+	if rv.Type().Key().Kind() != reflect.String {
+		return reflect.Value{}, fmt.Errorf("key type %s is not supported", rv.Type().Key())
+	}
+	kv := reflect.ValueOf(seg.name).Convert(rv.Type().Key())
+
+	if last {
+		if rv.IsNil() {
+			return reflect.Value{}, fmt.Errorf("map of %s is nil", seg.name)
+		}
+
+		ev := reflect.New(rv.Type().Elem()).Elem()
+		if err := assign(ev, seg.name, v); err != nil {
+			return reflect.Value{}, err
+		}
+		rv.SetMapIndex(kv, ev)
+		return reflect.Value{}, nil
+	}
+
+	ev := rv.MapIndex(kv)
+	if !ev.IsValid() {
+		return reflect.Value{}, newFieldNotFoundError(seg.name)
+	}
+
+	ev = indirect(ev)
+	switch {
+	case !ev.IsValid():
+		return reflect.Value{}, fmt.Errorf("name %s is nil and not settable", seg.name)
+	case ev.Kind() == reflect.Map:
+		return ev, nil
+	case ev.Kind() == reflect.Struct && ev.CanAddr():
+		return ev, nil
+	case ev.Kind() == reflect.Struct:
+		return reflect.Value{}, fmt.Errorf("name %s is not addressable. struct in map must be a pointer", seg.name)
+	default:
+		return reflect.Value{}, fmt.Errorf("name %s is not struct: %v", seg.name, ev.Kind())
+	}
+}
+
+// mapEntry returns the value of map rv keyed by name to set into the elements of it.
+func mapEntry(rv reflect.Value, name string) (reflect.Value, error) {
+	if rv.Type().Key().Kind() != reflect.String {
+		return reflect.Value{}, fmt.Errorf("key type %s is not supported", rv.Type().Key())
+	}
+
+	ev := rv.MapIndex(reflect.ValueOf(name).Convert(rv.Type().Key()))
+	if !ev.IsValid() {
+		return reflect.Value{}, newFieldNotFoundError(name)
+	}
+
+	return ev, nil
+}
+
+// setMapIndex sets v into the element of map mv indicated by a.
+// A nil map is allocated if it is settable. at and name are used for error messages.
+func setMapIndex(mv reflect.Value, a accessor, at string, name string, v interface{}) error {
+	if a.isWildcard() {
+		return fmt.Errorf("wildcard %s%s is not settable", at, a)
+	}
+
+	kv, err := mapKey(a.text, mv.Type().Key())
+	if err != nil {
+		return fmt.Errorf("%s has invalid key %q: %v", at, a.text, err)
+	}
+
+	if mv.IsNil() {
+		if !mv.CanSet() {
+			return fmt.Errorf("%s is nil and not settable", at)
+		}
+		mv.Set(reflect.MakeMap(mv.Type()))
+	}
+
+	ev := reflect.New(mv.Type().Elem()).Elem()
+	if err := assign(ev, name, v); err != nil {
+		return err
+	}
+	mv.SetMapIndex(kv, ev)
+
+	return nil
+}
+
+// refresh rebuilds Getters held in this Finder from the current original struct.
+// Nested structs that do not exist anymore (e.g. set to nil) are missing nodes, and errors are not held for them.
+func (f *Finder) refresh() {
+	keys := make([]string, 0, len(f.gMap))
+	for key := range f.gMap {
+		if key != topLevelKey {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	f.topLevelGetter.clearCache()
+	f.gMap = map[string]*node{topLevelKey: {getters: []*Getter{f.topLevelGetter}}}
+
+	// parent keys are sorted before their nested keys
+	for _, key := range keys {
+		names := f.nMap[key]
+		parent := f.pathKey(names[:len(names)-1])
+
+		var nd *node
+		if pn := f.gMap[parent]; pn != nil {
+			nd, _ = pn.into(names[len(names)-1])
+		}
+		f.gMap[key] = nd
+	}
+}
+
 // HasError tests whether this Finder have any errors.
 func (f *Finder) HasError() bool {
 	for _, errs := range f.eMap {
@@ -226,21 +1011,30 @@ func (f *Finder) HasError() bool {
 	return false
 }
 
+// Err returns *FinderError that holds all errors in this Finder by path.
+// nil is returned if this Finder does not have any errors.
+func (f *Finder) Err() error {
+	if !f.HasError() {
+		return nil
+	}
+
+	return newFinderError(f.eMap)
+}
+
 // Error returns error string.
+// Errors are sorted by paths as well as FinderError.
 func (f *Finder) Error() string {
-	var es []string
+	return newFinderError(f.eMap).Error()
+}
 
-	for _, errs := range f.eMap {
-		if len(errs) > 0 {
-			es = make([]string, len(errs))
-			for i, err := range errs {
-				es[i] = err.Error()
-			}
-		}
-	}
+// Is reports whether any error in this Finder matches target using errors.Is.
+func (f *Finder) Is(target error) bool {
+	return newFinderError(f.eMap).Is(target)
+}
 
-	// TODO: prettize
-	return strings.Join(es, "\n")
+// As finds the first error in this Finder that matches target using errors.As.
+func (f *Finder) As(target interface{}) bool {
+	return newFinderError(f.eMap).As(target)
 }
 
 // GetNameSeparator returns the separator string for nested struct name separating.
@@ -251,9 +1045,10 @@ func (f *Finder) GetNameSeparator() string {
 
 // Reset resets the current build Finder.
 func (f *Finder) Reset() *Finder {
-	gMap := map[string]*Getter{}
-	gMap[topLevelKey] = f.topLevelGetter
+	gMap := map[string]*node{}
+	gMap[topLevelKey] = &node{getters: []*Getter{f.topLevelGetter}}
 	f.gMap = gMap
+	f.nMap = map[string][]string{}
 
 	fMap := map[string][]string{}
 	f.fMap = fMap
@@ -261,6 +1056,9 @@ func (f *Finder) Reset() *Finder {
 	eMap := map[string][]error{}
 	f.eMap = eMap
 
+	f.sMap = map[string]*findSpec{}
+	f.missing = map[string]bool{}
+
 	f.ck = topLevelKey
 
 	return f
@@ -268,25 +1066,116 @@ func (f *Finder) Reset() *Finder {
 
 // FinderKeys is the struct that have keys for Finder.
 type FinderKeys struct {
-	keys []string
+	keys  []string
+	specs map[string]*findSpec
 }
 
+// Keys of the map that expresses the specification of a key in configuration file.
+const (
+	specKeyOptional  = "optional"
+	specKeyDefault   = "default"
+	specKeyRequired  = "required"
+	specKeyAlias     = "alias"
+	specKeyTransform = "transform"
+)
+
 type confKeys struct {
 	Keys []interface{}
 }
 
 // NewFinderKeys returns a FinderKeys object
 // that is created from configuration file indicated by dir and name file.
+//
+// A key can have the policy for a missing field as a map of "optional", "default" or "required".
+// Keys without policies are required.
+// A key can also have "alias" that is used as the key in the result of ToMap,
+// and "transform" that is the name of the transform applied to the value. See RegisterTransform.
+//
+//	Keys:
+//	  - Name
+//	  - Nickname:
+//	      optional: true
+//	  - Country:
+//	      default: JP
+//	  - Company:
+//	    - Boss:
+//	        alias: manager_name
+//	        transform: upper
 func NewFinderKeys(dir string, baseName string) (*FinderKeys, error) {
-	viper.SetConfigName(baseName)
-	viper.AddConfigPath(dir)
+	v := viper.New()
+	v.SetConfigName(baseName)
+	v.AddConfigPath(dir)
 
-	if err := viper.ReadInConfig(); err != nil {
+	if err := v.ReadInConfig(); err != nil {
 		return nil, err
 	}
 
+	path := v.ConfigFileUsed()
+	if format := strings.TrimPrefix(filepath.Ext(path), "."); isOrderedFormat(format) {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return newFinderKeysFromOrdered(b, format)
+	}
+
+	return newFinderKeysFromViper(v)
+}
+
+// NewFinderKeysFromReader returns a FinderKeys object
+// that is created from configuration read from r in format.
+// format is a configuration file type such as "yaml", "json" and "toml".
+func NewFinderKeysFromReader(r io.Reader, format string) (*FinderKeys, error) {
+	if !isSupportedFormat(format) {
+		return nil, viper.UnsupportedConfigError(format)
+	}
+
+	if isOrderedFormat(format) {
+		b, err := ioutil.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		return newFinderKeysFromOrdered(b, format)
+	}
+
+	v := viper.New()
+	v.SetConfigType(format)
+
+	if err := v.ReadConfig(r); err != nil {
+		return nil, err
+	}
+
+	return newFinderKeysFromViper(v)
+}
+
+// NewFinderKeysFromBytes returns a FinderKeys object
+// that is created from configuration b in format as well as NewFinderKeysFromReader.
+func NewFinderKeysFromBytes(b []byte, format string) (*FinderKeys, error) {
+	return NewFinderKeysFromReader(bytes.NewReader(b), format)
+}
+
+func isSupportedFormat(format string) bool {
+	for _, ext := range viper.SupportedExts {
+		if strings.EqualFold(format, ext) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newFinderKeysFromOrdered(b []byte, format string) (*FinderKeys, error) {
+	ck, err := parseOrderedConf(b, format)
+	if err != nil {
+		return nil, err
+	}
+
+	return newFinderKeysFromConf(ck)
+}
+
+func newFinderKeysFromViper(v *viper.Viper) (*FinderKeys, error) {
 	var ck confKeys
-	if err := viper.Unmarshal(&ck); err != nil {
+	if err := v.Unmarshal(&ck); err != nil {
 		return nil, err
 	}
 
@@ -298,7 +1187,7 @@ func newFinderKeysFromConf(ck confKeys) (*FinderKeys, error) {
 		return nil, fmt.Errorf("failed to parse or no keys exist in file")
 	}
 
-	fks := &FinderKeys{keys: make([]string, 0, len(ck.Keys)+1)}
+	fks := &FinderKeys{keys: make([]string, 0, len(ck.Keys)+1), specs: map[string]*findSpec{}}
 
 	var err error
 	for _, ckk := range ck.Keys {
@@ -308,9 +1197,36 @@ func newFinderKeysFromConf(ck confKeys) (*FinderKeys, error) {
 		}
 	}
 
+	if err := fks.checkDuplicates(); err != nil {
+		return nil, err
+	}
+
 	return fks, nil
 }
 
+// checkDuplicates returns an error if keys have the same path.
+// Keys that have braces are compared after expanded into paths.
+func (fks *FinderKeys) checkDuplicates() error {
+	seen := make(map[string]string, len(fks.keys))
+	for _, key := range fks.keys {
+		paths, err := parseQuery(key, defaultSep)
+		if err != nil {
+			// syntax errors are held by Finder in FromKeys
+			paths = [][]string{{key}}
+		}
+
+		for _, path := range paths {
+			pk := strings.Join(normalizeNames(path), defaultSep)
+			if prev, ok := seen[pk]; ok {
+				return fmt.Errorf("path %s is duplicated in keys %s and %s", pk, prev, key)
+			}
+			seen[pk] = key
+		}
+	}
+
+	return nil
+}
+
 func (fks *FinderKeys) addRecursive(key interface{}, prefix string) error {
 	var res string
 
@@ -321,35 +1237,24 @@ func (fks *FinderKeys) addRecursive(key interface{}, prefix string) error {
 			res = prefix + defaultSep + res
 		}
 		fks.keys = append(fks.keys, res) // set here
-	case map[string]interface{}:
-		var nk string
-		var nd interface{}
-		for key, value := range t {
-			nk = key
-			if prefix != "" {
-				nk = prefix + "." + nk
-			}
-			nd = value
-			break
-		}
-		err := fks.addRecursive(nd, nk)
+	case map[string]interface{}, map[interface{}]interface{}, yaml.MapSlice:
+		items, _, err := mapItems(t)
 		if err != nil {
-			return err
+			return fmt.Errorf("%v, prefix: %s", err, prefix)
+		}
+		if len(items) == 0 {
+			return fmt.Errorf("unsupported empty map, prefix: %s", prefix)
 		}
-	case map[interface{}]interface{}:
-		var nk string
-		var nd interface{}
-		for key, value := range t {
-			nk = key.(string)
+
+		for _, item := range items {
+			nk := item.Key.(string)
 			if prefix != "" {
-				nk = prefix + "." + nk
+				nk = prefix + defaultSep + nk
+			}
+
+			if err := fks.addRecursiveOrSpec(item.Value, nk); err != nil {
+				return err
 			}
-			nd = value
-			break
-		}
-		err := fks.addRecursive(nd, nk)
-		if err != nil {
-			return err
 		}
 	case []interface{}:
 		var err error
@@ -366,6 +1271,126 @@ func (fks *FinderKeys) addRecursive(key interface{}, prefix string) error {
 	return nil
 }
 
+// addRecursiveOrSpec adds key with the specification if value is the map for the specification.
+// Otherwise value is added as nested keys of key.
+func (fks *FinderKeys) addRecursiveOrSpec(value interface{}, key string) error {
+	spec, ok, err := parseFindSpec(value)
+	if err != nil {
+		return fmt.Errorf("invalid specification of key %s: %w", key, err)
+	}
+	if !ok {
+		return fks.addRecursive(value, key)
+	}
+
+	fks.keys = append(fks.keys, key)
+	if spec != nil {
+		fks.specs[key] = spec
+	}
+
+	return nil
+}
+
+// parseFindSpec parses v as the map for the specification of a key.
+// ok is false if v is not the map that has only keys for the specification.
+// spec is nil if the field is required and does not have any other specifications.
+// An error is returned if keys for the specification and names of fields are mixed in the map.
+func parseFindSpec(v interface{}) (spec *findSpec, ok bool, err error) {
+	items, isMap, err := mapItems(v)
+	if !isMap || err != nil || len(items) == 0 {
+		// errors are returned by addRecursive
+		return nil, false, nil
+	}
+
+	m := make(map[string]interface{}, len(items))
+	var names []string
+	for _, item := range items {
+		k := item.Key.(string)
+		switch k {
+		case specKeyOptional, specKeyDefault, specKeyRequired, specKeyAlias, specKeyTransform:
+			if _, dup := m[k]; dup {
+				return nil, true, fmt.Errorf("%s is duplicated", k)
+			}
+			m[k] = item.Value
+		default:
+			names = append(names, k)
+		}
+	}
+
+	if len(m) == 0 {
+		return nil, false, nil
+	}
+	if len(names) > 0 {
+		return nil, true, fmt.Errorf("ambiguous map that has both keys for the specification and names %v", names)
+	}
+
+	flag := func(k string) (bool, error) {
+		e, has := m[k]
+		if !has {
+			return false, nil
+		}
+		b, isBool := e.(bool)
+		if !isBool {
+			return false, fmt.Errorf("%s must be bool: %#v", k, e)
+		}
+		return b, nil
+	}
+	str := func(k string) (string, error) {
+		e, has := m[k]
+		if !has {
+			return "", nil
+		}
+		s, isStr := e.(string)
+		if !isStr || s == "" {
+			return "", fmt.Errorf("%s must be non-empty string: %#v", k, e)
+		}
+		return s, nil
+	}
+
+	optional, err := flag(specKeyOptional)
+	if err != nil {
+		return nil, true, err
+	}
+	required, err := flag(specKeyRequired)
+	if err != nil {
+		return nil, true, err
+	}
+	def, hasDefault := m[specKeyDefault]
+	def = plainValue(def)
+	if required && (optional || hasDefault) {
+		return nil, true, fmt.Errorf("required field can not be optional or have default")
+	}
+
+	alias, err := str(specKeyAlias)
+	if err != nil {
+		return nil, true, err
+	}
+	transform, err := str(specKeyTransform)
+	if err != nil {
+		return nil, true, err
+	}
+
+	spec = &findSpec{
+		optional:   optional || hasDefault,
+		hasDefault: hasDefault,
+		def:        def,
+		alias:      alias,
+		transform:  transform,
+	}
+	if transform != "" {
+		fn, ok := lookupTransform(transform)
+		if !ok {
+			return nil, true, fmt.Errorf("transform %s is not registered", transform)
+		}
+		spec.transformFn = fn
+	}
+
+	if !spec.optional && alias == "" && transform == "" {
+		return nil, true, nil
+	}
+
+	return spec, true, nil
+}
+
 // Len returns length of FinderKeys
 func (fks *FinderKeys) Len() int {
 	return len(fks.keys)
@@ -375,12 +1400,3 @@ func (fks *FinderKeys) Len() int {
 func (fks *FinderKeys) Keys() []string {
 	return fks.keys
 }
-
-func (fks *FinderKeys) intoAndFindNames(i int) (string, string) {
-	s := strings.Split(fks.keys[i], defaultSep)
-	if len(s) == 1 {
-		return topLevelKey, s[0]
-	}
-
-	return strings.Join(s[0:len(s)-1], defaultSep), s[len(s)-1]
-}
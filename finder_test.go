@@ -1,8 +1,14 @@
 package structil_test
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"reflect"
 	"strconv"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -350,7 +356,7 @@ func TestToMap(t *testing.T) {
 				chain: fs[6].Into("NonExist").Find("String"),
 			},
 			wantError:       true,
-			wantErrorString: "Error in name: NonExist, key: NonExist. [name NonExist does not exist]",
+			wantErrorString: "Error in name: NonExist, key: NonExist. [field name NonExist does not exist]",
 		},
 		{
 			name: "with Struct with existed name and Find with non-existed name",
@@ -368,7 +374,7 @@ func TestToMap(t *testing.T) {
 					Into("FinderTestStruct2", "NonExist").Find("String"),
 			},
 			wantError:       true,
-			wantErrorString: "Error in name: NonExist, key: FinderTestStruct2.NonExist. [name NonExist does not exist]",
+			wantErrorString: "Error in name: NonExist, key: FinderTestStruct2.NonExist. [field name NonExist does not exist]",
 		},
 		{
 			name: "with multi nest chains separated by assigned sep",
@@ -488,8 +494,1376 @@ func TestToMap(t *testing.T) {
 	}
 }
 
-// This test should *NOT* be parallel
+func TestToMapWithAccessors(t *testing.T) {
+	t.Parallel()
+
+	type accessorTestStruct struct {
+		Labels map[string]string
+		Ints   map[int]*FinderTestStruct4
+		Array  [2]FinderTestStruct4
+		Intfs  []interface{}
+		Named  map[fmt.Stringer]int
+	}
+
+	newFinder := func() *Finder {
+		f, err := NewFinder(&accessorTestStruct{
+			Labels: map[string]string{"env": "prod", "a.b": "dotted"},
+			Ints:   map[int]*FinderTestStruct4{10: {String: "ten"}},
+			Array:  [2]FinderTestStruct4{{String: "a0"}, {String: "a1"}},
+			Intfs:  []interface{}{FinderTestStruct4{String: "intf0"}},
+		})
+		if err != nil {
+			t.Fatalf("NewFinder() error = %v", err)
+		}
+		return f
+	}
+
+	fs := make([]*Finder, 7)
+	for i := 0; i < len(fs); i++ {
+		f, err := NewFinder(newFinderTestStructPtr())
+		if err != nil {
+			t.Fatalf("NewFinder() error = %v", err)
+		}
+		fs[i] = f
+	}
+
+	type args struct {
+		chain *Finder
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantError       bool
+		wantErrorString string
+		wantMap         map[string]interface{}
+	}{
+		{
+			name: "with slice index and map key",
+			args: args{
+				chain: fs[0].Find("Stringslice[1]", `Map["k1"]`, "Map[k2]", "FinderTestStruct4PtrSlice[0]"),
+			},
+			wantMap: map[string]interface{}{
+				"Stringslice[1]":               "strslice2",
+				`Map["k1"]`:                    "v1",
+				"Map[k2]":                      2,
+				"FinderTestStruct4PtrSlice[0]": FinderTestStruct4{String: "key991", String2: "value991"}, // not ptr
+			},
+		},
+		{
+			name: "with Into slice index",
+			args: args{
+				chain: fs[1].Into("FinderTestStruct4Slice[1]").Find("String", "String2"),
+			},
+			wantMap: map[string]interface{}{
+				"FinderTestStruct4Slice[1].String":  "key200",
+				"FinderTestStruct4Slice[1].String2": "value200",
+			},
+		},
+		{
+			name: "with array, int keyed map, interface slice and key including separator",
+			args: args{
+				chain: newFinder().
+					Find(`Labels["env"]`, `Labels["a.b"]`, "Array[1]").
+					Into("Ints[10]").Find("String").
+					Into("Intfs[0]").Find("String"),
+			},
+			wantMap: map[string]interface{}{
+				`Labels["env"]`:   "prod",
+				`Labels["a.b"]`:   "dotted",
+				"Array[1]":        FinderTestStruct4{String: "a1"},
+				"Ints[10].String": "ten",
+				"Intfs[0].String": "intf0",
+			},
+		},
+		{
+			name: "with out of range index",
+			args: args{
+				chain: fs[2].Find("Stringslice[5]"),
+			},
+			wantError:       true,
+			wantErrorString: "index 5 is out of range of Stringslice (len 2)",
+		},
+		{
+			name: "with non-existed map key",
+			args: args{
+				chain: fs[3].Find(`Map["nokey"]`),
+			},
+			wantError:       true,
+			wantErrorString: `key "nokey" does not exist in Map`,
+		},
+		{
+			name: "with Into out of range index",
+			args: args{
+				chain: fs[4].Into("FinderTestStruct4Slice[9]").Find("String"),
+			},
+			wantError:       true,
+			wantErrorString: "Error in name: FinderTestStruct4Slice[9], key: FinderTestStruct4Slice[9]. [index 9 is out of range of FinderTestStruct4Slice (len 2)]",
+		},
+		{
+			name: "with non-integer slice index",
+			args: args{
+				chain: fs[5].Find("Stringslice[x]"),
+			},
+			wantError:       true,
+			wantErrorString: "Stringslice is slice. index must be an integer: x",
+		},
+		{
+			name: "with accessor for non-collection field",
+			args: args{
+				chain: fs[6].Find("String[0]"),
+			},
+			wantError:       true,
+			wantErrorString: "String is not slice, array or map: string",
+		},
+		{
+			name: "with map keyed by non-empty interface",
+			args: args{
+				chain: newFinder().Find(`Named["k"]`),
+			},
+			wantError:       true,
+			wantErrorString: `Named has invalid key "k": key type fmt.Stringer is not supported`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.args.chain.ToMap()
+
+			if err == nil {
+				if tt.wantError {
+					t.Errorf("error does not occur. got: %v", got)
+					return
+				}
+
+				if d := cmp.Diff(got, tt.wantMap); d != "" {
+					t.Errorf("(-got +want)\n%s", d)
+				}
+			} else if !tt.wantError {
+				t.Errorf("unexpected error = %v", err)
+			} else if d := cmp.Diff(err.Error(), tt.wantErrorString); d != "" {
+				t.Errorf("error string is unmatch. (-got +want)\n%s", d)
+			}
+		})
+	}
+}
+
+func TestToMapWithWildcards(t *testing.T) {
+	t.Parallel()
+
+	type wildcardTestStruct struct {
+		Groups []*FinderTestStruct2
+	}
+
+	fw, err := NewFinder(&wildcardTestStruct{
+		Groups: []*FinderTestStruct2{
+			{String: "g0", FinderTestStruct3: &FinderTestStruct3{Int: 0}},
+			nil,
+			{String: "g2", FinderTestStruct3: &FinderTestStruct3{Int: 2}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewFinder() error = %v", err)
+	}
+
+	fs := make([]*Finder, 6)
+	for i := 0; i < len(fs); i++ {
+		f, err := NewFinder(newFinderTestStructPtr())
+		if err != nil {
+			t.Fatalf("NewFinder() error = %v", err)
+		}
+		fs[i] = f
+	}
+
+	type args struct {
+		chain *Finder
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantError       bool
+		wantErrorString string
+		wantMap         map[string]interface{}
+	}{
+		{
+			name: "with wildcard index and key",
+			args: args{
+				chain: fs[0].Find("Stringslice[*]", "Map[*]", "FinderTestStruct4PtrSlice[*]"),
+			},
+			wantMap: map[string]interface{}{
+				"Stringslice[*]": []interface{}{"strslice1", "strslice2"},
+				"Map[*]":         []interface{}{"v1", 2}, // sorted by keys
+				"FinderTestStruct4PtrSlice[*]": []interface{}{
+					FinderTestStruct4{String: "key991", String2: "value991"},
+					FinderTestStruct4{String: "key992", String2: "value992"},
+				},
+			},
+		},
+		{
+			name: "with Into wildcard",
+			args: args{
+				chain: fs[1].
+					Into("FinderTestStruct4Slice[*]").Find("String2").
+					Into("FinderTestStruct4PtrSlice", "*").Find("String"),
+			},
+			wantMap: map[string]interface{}{
+				"FinderTestStruct4Slice[*].String2":   []interface{}{"value100", "value200"},
+				"FinderTestStruct4PtrSlice[*].String": []interface{}{"key991", "key992"},
+			},
+		},
+		{
+			name: "with wildcard name",
+			args: args{
+				chain: fs[2].Into("FinderTestStruct2Ptr").Find("*"),
+			},
+			wantMap: map[string]interface{}{
+				"FinderTestStruct2Ptr.*": []interface{}{
+					"struct2 string ptr",
+					FinderTestStruct3{String: "struct3 string ptr", Int: -456},
+				},
+			},
+		},
+		{
+			name: "with nested fan-out and nil elements",
+			args: args{
+				chain: fw.
+					Find("Groups[*]").
+					Into("Groups[*]", "FinderTestStruct3").Find("Int"),
+			},
+			wantMap: map[string]interface{}{
+				"Groups[*]": []interface{}{
+					FinderTestStruct2{String: "g0", FinderTestStruct3: &FinderTestStruct3{Int: 0}},
+					nil,
+					FinderTestStruct2{String: "g2", FinderTestStruct3: &FinderTestStruct3{Int: 2}},
+				},
+				"Groups[*].FinderTestStruct3.Int": []interface{}{0, 2},
+			},
+		},
+		{
+			name: "with non-existed name in fanned out structs",
+			args: args{
+				chain: fs[3].Into("FinderTestStruct4Slice[*]").Find("NonExist"),
+			},
+			wantError:       true,
+			wantErrorString: "field name NonExist does not exist",
+		},
+		{
+			name: "with wildcard for non-collection field",
+			args: args{
+				chain: fs[4].Find("String[*]"),
+			},
+			wantError:       true,
+			wantErrorString: "String is not slice, array, map or struct: string",
+		},
+		{
+			name: "with Into wildcard for non-struct elements",
+			args: args{
+				chain: fs[5].Into("Stringslice[*]").Find("String"),
+			},
+			wantError:       true,
+			wantErrorString: "Error in name: Stringslice[*], key: Stringslice[*]. [strslice1 is not supported kind: string. value: strslice1]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.args.chain.ToMap()
+
+			if err == nil {
+				if tt.wantError {
+					t.Errorf("error does not occur. got: %v", got)
+					return
+				}
+
+				if d := cmp.Diff(got, tt.wantMap); d != "" {
+					t.Errorf("(-got +want)\n%s", d)
+				}
+			} else if !tt.wantError {
+				t.Errorf("unexpected error = %v", err)
+			} else if d := cmp.Diff(err.Error(), tt.wantErrorString); d != "" {
+				t.Errorf("error string is unmatch. (-got +want)\n%s", d)
+			}
+		})
+	}
+}
+
+func TestToNestedMap(t *testing.T) {
+	t.Parallel()
+
+	fs := make([]*Finder, 4)
+	for i := 0; i < len(fs); i++ {
+		f, err := NewFinder(newFinderTestStructPtr())
+		if err != nil {
+			t.Fatalf("NewFinder() error = %v", err)
+		}
+		fs[i] = f
+	}
+
+	fsep, err := NewFinderWithSep(newFinderTestStructPtr(), ":")
+	if err != nil {
+		t.Fatalf("NewFinderWithSep() error = %v", err)
+	}
+
+	type args struct {
+		chain *Finder
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantError       bool
+		wantErrorString string
+		wantMap         map[string]interface{}
+	}{
+		{
+			name: "with toplevel and nested find chain",
+			args: args{
+				chain: fs[0].
+					Find("Int64", "Map").
+					Into("FinderTestStruct2").Find("String").
+					Into("FinderTestStruct2", "FinderTestStruct3").Find("String", "Int").
+					Into("FinderTestStruct2Ptr").Find("String"),
+			},
+			wantMap: map[string]interface{}{
+				"Int64": int64(-1),
+				"Map":   map[string]interface{}{"k1": "v1", "k2": 2},
+				"FinderTestStruct2": map[string]interface{}{
+					"String": "struct2 string",
+					"FinderTestStruct3": map[string]interface{}{
+						"String": "struct3 string",
+						"Int":    -123,
+					},
+				},
+				"FinderTestStruct2Ptr": map[string]interface{}{
+					"String": "struct2 string ptr",
+				},
+			},
+		},
+		{
+			name: "with accessors and wildcards",
+			args: args{
+				chain: fs[1].
+					Into("FinderTestStruct4Slice[1]").Find("String").
+					Into("FinderTestStruct4PtrSlice[*]").Find("String2"),
+			},
+			wantMap: map[string]interface{}{
+				"FinderTestStruct4Slice[1]":    map[string]interface{}{"String": "key200"},
+				"FinderTestStruct4PtrSlice[*]": map[string]interface{}{"String2": []interface{}{"value991", "value992"}},
+			},
+		},
+		{
+			name: "with separator",
+			args: args{
+				chain: fsep.Into("FinderTestStruct2Ptr", "FinderTestStruct3").Find("Int"),
+			},
+			wantMap: map[string]interface{}{
+				"FinderTestStruct2Ptr": map[string]interface{}{
+					"FinderTestStruct3": map[string]interface{}{"Int": -456},
+				},
+			},
+		},
+		{
+			name: "with name looked up as both value and nested map",
+			args: args{
+				chain: fs[2].
+					Find("FinderTestStruct2").
+					Into("FinderTestStruct2").Find("String"),
+			},
+			wantError:       true,
+			wantErrorString: "Error in key: FinderTestStruct2.String. [name FinderTestStruct2 is looked up both as a value and as a nested map]",
+		},
+		{
+			name: "with non-existed name",
+			args: args{
+				chain: fs[3].Into("FinderTestStruct2").Find("NonExist"),
+			},
+			wantError:       true,
+			wantErrorString: "field name NonExist does not exist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.args.chain.ToNestedMap()
+
+			if err == nil {
+				if tt.wantError {
+					t.Errorf("error does not occur. got: %v", got)
+					return
+				}
+
+				if d := cmp.Diff(got, tt.wantMap); d != "" {
+					t.Errorf("(-got +want)\n%s", d)
+				}
+			} else if !tt.wantError {
+				t.Errorf("unexpected error = %v", err)
+			} else if d := cmp.Diff(err.Error(), tt.wantErrorString); d != "" {
+				t.Errorf("error string is unmatch. (-got +want)\n%s", d)
+			}
+		})
+	}
+}
+
+func TestToStruct(t *testing.T) {
+	t.Parallel()
+
+	type taggedStruct struct {
+		ID    int    `json:"id"`
+		Name  string `json:"name,omitempty"`
+		Inner struct {
+			Value *string `json:"value"`
+		} `json:"inner"`
+	}
+
+	ts := &taggedStruct{ID: 1, Name: "n"}
+	ts.Inner.Value = &finderTestString2
+
+	tests := []struct {
+		name            string
+		chain           func() (*Finder, error)
+		wantError       bool
+		wantErrorString string
+		wantJSON        string
+	}{
+		{
+			name: "with toplevel and nested find chain",
+			chain: func() (*Finder, error) {
+				f, err := NewFinder(newFinderTestStructPtr())
+				if err != nil {
+					return nil, err
+				}
+				return f.
+					Find("Int64", "Stringptr", "Stringslice").
+					Into("FinderTestStruct2Ptr").Find("String").
+					Into("FinderTestStruct2Ptr", "FinderTestStruct3").Find("Int"), nil
+			},
+			wantJSON: `{"FinderTestStruct2Ptr":{"FinderTestStruct3":{"Int":-456},"String":"struct2 string ptr"},"Int64":-1,"Stringptr":"test name2","Stringslice":["strslice1","strslice2"]}`,
+		},
+		{
+			name: "with tagged names",
+			chain: func() (*Finder, error) {
+				g, err := NewGetterWithTag(ts, "json")
+				if err != nil {
+					return nil, err
+				}
+				f, err := NewFinderWithGetter(g)
+				if err != nil {
+					return nil, err
+				}
+				return f.Find("id", "name").Into("inner").Find("value"), nil
+			},
+			wantJSON: `{"id":1,"inner":{"value":"test name2"},"name":"n"}`,
+		},
+		{
+			name: "with map",
+			chain: func() (*Finder, error) {
+				f, err := NewFinder(map[string]interface{}{"Name": "n", "Child": map[string]interface{}{"ID": 2}})
+				if err != nil {
+					return nil, err
+				}
+				return f.Find("Name").Into("Child").Find("ID"), nil
+			},
+			wantJSON: `{"Child":{"ID":2},"Name":"n"}`,
+		},
+		{
+			name: "with unexported name",
+			chain: func() (*Finder, error) {
+				f, err := NewFinder(newFinderTestStructPtr())
+				if err != nil {
+					return nil, err
+				}
+				return f.Find("privateString"), nil
+			},
+			wantError:       true,
+			wantErrorString: "Error in name: privateString, key: privateString. [field privateString: unexported field can not be accessed]",
+		},
+		{
+			name: "with wildcard",
+			chain: func() (*Finder, error) {
+				f, err := NewFinder(newFinderTestStructPtr())
+				if err != nil {
+					return nil, err
+				}
+				return f.Into("FinderTestStruct4Slice[*]").Find("String"), nil
+			},
+			wantError:       true,
+			wantErrorString: "Error in name: String, key: FinderTestStruct4Slice[*].String. [name FinderTestStruct4Slice[*] has accessors or wildcards]",
+		},
+		{
+			name: "with name looked up as both value and nested struct",
+			chain: func() (*Finder, error) {
+				f, err := NewFinder(newFinderTestStructPtr())
+				if err != nil {
+					return nil, err
+				}
+				return f.Find("FinderTestStruct2Ptr").Into("FinderTestStruct2Ptr").Find("String"), nil
+			},
+			wantError:       true,
+			wantErrorString: "Error in name: String, key: FinderTestStruct2Ptr.String. [name FinderTestStruct2Ptr is looked up both as a value and as a nested struct]",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := tt.chain()
+			if err != nil {
+				t.Fatalf("unexpected error [%v] occured.", err)
+			}
+
+			got, ds, err := f.ToStruct()
+			if err == nil {
+				if tt.wantError {
+					t.Errorf("error does not occur. got: %v", got)
+					return
+				}
+
+				if reflect.TypeOf(got).Kind() != reflect.Ptr || reflect.TypeOf(got).Elem().NumField() != ds.NumField() {
+					t.Errorf("unexpected result type: %T", got)
+				}
+
+				// field order of DynamicStruct is not fixed, so compare the decoded JSON
+				b, err := json.Marshal(got)
+				if err != nil {
+					t.Fatalf("json.Marshal() unexpected error [%v] occured.", err)
+				}
+				var gotJSON, wantJSON interface{}
+				if err := json.Unmarshal(b, &gotJSON); err != nil {
+					t.Fatalf("json.Unmarshal() unexpected error [%v] occured.", err)
+				}
+				if err := json.Unmarshal([]byte(tt.wantJSON), &wantJSON); err != nil {
+					t.Fatalf("json.Unmarshal() unexpected error [%v] occured.", err)
+				}
+				if d := cmp.Diff(gotJSON, wantJSON); d != "" {
+					t.Errorf("(-got +want)\n%s", d)
+				}
+			} else if !tt.wantError {
+				t.Errorf("unexpected error = %v", err)
+			} else if d := cmp.Diff(err.Error(), tt.wantErrorString); d != "" {
+				t.Errorf("error string is unmatch. (-got +want)\n%s", d)
+			}
+		})
+	}
+}
+
+func TestFinderSet(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		i    interface{}
+		into []string
+		m    map[string]interface{}
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantError       bool
+		wantErrorString string
+		wantMap         map[string]interface{}
+		wantIntoMap     map[string]interface{}
+	}{
+		{
+			name: "with toplevel and nested keys",
+			args: args{
+				i:    newFinderTestStructPtr(),
+				into: []string{"FinderTestStruct2Ptr", "FinderTestStruct3"},
+				m: map[string]interface{}{
+					"String":                                 "new name",
+					"FinderTestStruct2Ptr.String":            "new struct2 string ptr",
+					"FinderTestStruct2Ptr.FinderTestStruct3": &FinderTestStruct3{String: "new struct3", Int: 1},
+				},
+			},
+			wantMap: map[string]interface{}{
+				"String":                                 "new name",
+				"FinderTestStruct2Ptr.String":            "new struct2 string ptr",
+				"FinderTestStruct2Ptr.FinderTestStruct3": FinderTestStruct3{String: "new struct3", Int: 1},
+			},
+			wantIntoMap: map[string]interface{}{
+				"FinderTestStruct2Ptr.FinderTestStruct3.String": "new struct3",
+				"FinderTestStruct2Ptr.FinderTestStruct3.Int":    1,
+			},
+		},
+		{
+			name: "with nil intermediate pointers",
+			args: args{
+				i: &FinderTestStruct{},
+				m: map[string]interface{}{
+					"FinderTestStruct2Ptr.FinderTestStruct3.Int": 10,
+					"Stringptr": "string ptr",
+				},
+			},
+			wantMap: map[string]interface{}{
+				"FinderTestStruct2Ptr.FinderTestStruct3.Int": 10,
+				"Stringptr": "string ptr",
+			},
+		},
+		{
+			name: "with slice index",
+			args: args{
+				i: newFinderTestStructPtr(),
+				m: map[string]interface{}{
+					"Stringslice[0]":                    "new strslice1",
+					"FinderTestStruct4Slice[1].String2": "new value200",
+				},
+			},
+			wantMap: map[string]interface{}{
+				"Stringslice":                       []string{"new strslice1", "strslice2"},
+				"FinderTestStruct4Slice[1].String2": "new value200",
+			},
+		},
+		{
+			name: "with map key",
+			args: args{
+				i: newFinderTestStructPtr(),
+				m: map[string]interface{}{`Map["k1"]`: "new v1", `Map["k3"]`: 3},
+			},
+			wantMap: map[string]interface{}{
+				`Map["k1"]`: "new v1",
+				`Map["k3"]`: 3,
+			},
+		},
+		{
+			name: "with non-existed name",
+			args: args{
+				i: newFinderTestStructPtr(),
+				m: map[string]interface{}{"FinderTestStruct2Ptr.NonExist": 1},
+			},
+			wantError:       true,
+			wantErrorString: "Error in name: NonExist, key: FinderTestStruct2Ptr.NonExist. [field name NonExist does not exist]",
+		},
+		{
+			name: "with unmatched type",
+			args: args{
+				i: newFinderTestStructPtr(),
+				m: map[string]interface{}{"Int64": 1},
+			},
+			wantError:       true,
+			wantErrorString: "Error in name: Int64, key: Int64. [field Int64: type mismatch. expected: int64, actual: int]",
+		},
+		{
+			name: "with non-struct intermediate name",
+			args: args{
+				i: newFinderTestStructPtr(),
+				m: map[string]interface{}{"String.Int": 1},
+			},
+			wantError:       true,
+			wantErrorString: "Error in name: String, key: String.Int. [name String is not struct: string]",
+		},
+		{
+			name: "with struct value",
+			args: args{
+				i: newFinderTestStruct(),
+				m: map[string]interface{}{"String": "new name"},
+			},
+			wantError:       true,
+			wantErrorString: "Error in name: String, key: String. [struct is not addressable. Finder must be created from a struct pointer]",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := NewFinder(tt.args.i)
+			if err != nil {
+				t.Fatalf("NewFinder() error = %v", err)
+			}
+
+			// Getters looked up before Set must be refreshed
+			if tt.args.into != nil {
+				f.Into(tt.args.into...)
+			}
+
+			err = f.Set(tt.args.m)
+			if err != nil {
+				if !tt.wantError {
+					t.Errorf("Set() unexpected error = %v", err)
+				} else if d := cmp.Diff(err.Error(), tt.wantErrorString); d != "" {
+					t.Errorf("error string is unmatch. (-got +want)\n%s", d)
+				}
+				return
+			}
+			if tt.wantError {
+				t.Errorf("Set() error does not occur")
+				return
+			}
+
+			if tt.wantIntoMap != nil {
+				got, err := f.Find("String", "Int").ToMap()
+				if err != nil {
+					t.Errorf("ToMap() unexpected error = %v", err)
+					return
+				}
+				if d := cmp.Diff(got, tt.wantIntoMap); d != "" {
+					t.Errorf("Getters are not refreshed. (-got +want)\n%s", d)
+				}
+			}
+
+			for k, wv := range tt.wantMap {
+				f.Reset()
+				names := strings.Split(k, ".")
+				if len(names) > 1 {
+					f.Into(names[:len(names)-1]...)
+				}
+				got, err := f.Find(names[len(names)-1]).ToMap()
+				if err != nil {
+					t.Errorf("ToMap() unexpected error = %v", err)
+					return
+				}
+				if d := cmp.Diff(got[k], wv); d != "" {
+					t.Errorf("key: %s, (-got +want)\n%s", k, d)
+				}
+			}
+		})
+	}
+}
+
+func TestFinderSetWithMapAccessors(t *testing.T) {
+	t.Parallel()
+
+	type mapAccessorTestStruct struct {
+		Ints    map[string]int
+		Ptrs    map[string]*FinderTestStruct4
+		Structs map[string]FinderTestStruct4
+		Nil     map[int]string
+	}
+
+	newFinder := func(t *testing.T) (*Finder, *mapAccessorTestStruct) {
+		s := &mapAccessorTestStruct{
+			Ints:    map[string]int{"k1": 1},
+			Ptrs:    map[string]*FinderTestStruct4{"p": {String: "p"}},
+			Structs: map[string]FinderTestStruct4{"s": {String: "s"}},
+		}
+		f, err := NewFinder(s)
+		if err != nil {
+			t.Fatalf("NewFinder() unexpected error [%v] occured.", err)
+		}
+		return f, s
+	}
+
+	tests := []struct {
+		name            string
+		path            string
+		value           interface{}
+		wantErrorString string
+		got             func(*mapAccessorTestStruct) interface{}
+		want            interface{}
+	}{
+		{
+			name:  "with new key",
+			path:  `Ints["k2"]`,
+			value: 2,
+			got:   func(s *mapAccessorTestStruct) interface{} { return s.Ints },
+			want:  map[string]int{"k1": 1, "k2": 2},
+		},
+		{
+			name:  "with field of pointer element",
+			path:  `Ptrs["p"].String`,
+			value: "new p",
+			got:   func(s *mapAccessorTestStruct) interface{} { return s.Ptrs["p"].String },
+			want:  "new p",
+		},
+		{
+			name:  "with nil map",
+			path:  "Nil[1]",
+			value: "one",
+			got:   func(s *mapAccessorTestStruct) interface{} { return s.Nil },
+			want:  map[int]string{1: "one"},
+		},
+		{
+			name:            "with field of struct element",
+			path:            `Structs["s"].String`,
+			value:           "new s",
+			wantErrorString: `Error in name: Structs["s"], key: Structs["s"].String. [element Structs["s"] of map is not settable. it must be a pointer]`,
+		},
+		{
+			name:            "with invalid key",
+			path:            "Nil[x]",
+			value:           "x",
+			wantErrorString: `Error in name: Nil[x], key: Nil[x]. [Nil has invalid key "x": strconv.ParseInt: parsing "x": invalid syntax]`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f, s := newFinder(t)
+			err := f.SetPath(tt.path, tt.value)
+			if tt.wantErrorString != "" {
+				if err == nil || err.Error() != tt.wantErrorString {
+					t.Errorf("SetPath() unexpected error. got: %v, want: %s", err, tt.wantErrorString)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("SetPath() unexpected error [%v] occured.", err)
+			}
+			if d := cmp.Diff(tt.got(s), tt.want); d != "" {
+				t.Errorf("SetPath() unexpected result. (-got +want)\n%s", d)
+			}
+		})
+	}
+}
+
+func TestFinderSetNilIntermediate(t *testing.T) {
+	t.Parallel()
+
+	f, err := NewFinder(newFinderTestStructPtr())
+	if err != nil {
+		t.Fatalf("NewFinder() unexpected error [%v] occured.", err)
+	}
+	f.Into("FinderTestStruct2Ptr", "FinderTestStruct3").FindOptional("Int")
+
+	// nested structs held by Into are missing after they are set to nil
+	if err := f.SetPath("FinderTestStruct2Ptr", nil); err != nil {
+		t.Fatalf("SetPath() unexpected error [%v] occured.", err)
+	}
+	if err := f.SetPath("String", "new name"); err != nil {
+		t.Fatalf("SetPath() unexpected error [%v] occured.", err)
+	}
+
+	got, err := f.ToMap()
+	if err != nil {
+		t.Fatalf("ToMap() unexpected error [%v] occured.", err)
+	}
+	if d := cmp.Diff(got, map[string]interface{}{}); d != "" {
+		t.Errorf("ToMap() unexpected result. (-got +want)\n%s", d)
+	}
+
+	// nested structs are looked up again after they are set
+	if err := f.SetPath("FinderTestStruct2Ptr.FinderTestStruct3.Int", 10); err != nil {
+		t.Fatalf("SetPath() unexpected error [%v] occured.", err)
+	}
+
+	got, err = f.ToMap()
+	if err != nil {
+		t.Fatalf("ToMap() unexpected error [%v] occured.", err)
+	}
+	want := map[string]interface{}{"FinderTestStruct2Ptr.FinderTestStruct3.Int": 10}
+	if d := cmp.Diff(got, want); d != "" {
+		t.Errorf("ToMap() unexpected result. (-got +want)\n%s", d)
+	}
+}
+
+func TestFinderWithEscapedNames(t *testing.T) {
+	t.Parallel()
+
+	type escapedGroup struct {
+		Name string
+	}
+	type escapedStruct struct {
+		Labels map[string]*escapedGroup
+		Group  *escapedGroup
+	}
+
+	newFinder := func(t *testing.T) *Finder {
+		f, err := NewFinder(&escapedStruct{
+			Labels: map[string]*escapedGroup{"a.b": {Name: "ab"}, "a": {Name: "a"}},
+			Group:  &escapedGroup{Name: "g"},
+		})
+		if err != nil {
+			t.Fatalf("NewFinder() unexpected error [%v] occured.", err)
+		}
+		return f.Query(`Labels.a\.b.Name`)
+	}
+
+	t.Run("ToNestedMap", func(t *testing.T) {
+		got, err := newFinder(t).ToNestedMap()
+		if err != nil {
+			t.Fatalf("ToNestedMap() unexpected error [%v] occured.", err)
+		}
+
+		want := map[string]interface{}{
+			"Labels": map[string]interface{}{"a.b": map[string]interface{}{"Name": "ab"}},
+		}
+		if d := cmp.Diff(got, want); d != "" {
+			t.Errorf("ToNestedMap() unexpected result. (-got +want)\n%s", d)
+		}
+	})
+
+	t.Run("Set", func(t *testing.T) {
+		f := newFinder(t)
+		if err := f.SetPath("Group.Name", "new g"); err != nil {
+			t.Fatalf("SetPath() unexpected error [%v] occured.", err)
+		}
+
+		got, err := f.Query("Group.Name").ToMap()
+		if err != nil {
+			t.Fatalf("ToMap() unexpected error [%v] occured.", err)
+		}
+
+		want := map[string]interface{}{
+			"Labels.a.b.Name": "ab",
+			"Group.Name":      "new g",
+		}
+		if d := cmp.Diff(got, want); d != "" {
+			t.Errorf("ToMap() unexpected result. (-got +want)\n%s", d)
+		}
+	})
+
+	t.Run("with escaped and nested names", func(t *testing.T) {
+		m := map[string]interface{}{
+			"a.b": map[string]interface{}{"c": 1},
+			"a":   map[string]interface{}{"b": map[string]interface{}{"c": 2}},
+		}
+
+		f, err := NewFinder(m)
+		if err != nil {
+			t.Fatalf("NewFinder() unexpected error [%v] occured.", err)
+		}
+		got, err := f.Query(`a\.b.c`).ToMap()
+		if err != nil {
+			t.Fatalf("ToMap() unexpected error [%v] occured.", err)
+		}
+		if d := cmp.Diff(got, map[string]interface{}{"a.b.c": 1}); d != "" {
+			t.Errorf("ToMap() unexpected result. (-got +want)\n%s", d)
+		}
+
+		f, err = NewFinder(m)
+		if err != nil {
+			t.Fatalf("NewFinder() unexpected error [%v] occured.", err)
+		}
+		_, err = f.Query(`a\.b.c`).Query("a.b.c").ToMap()
+
+		var fe *FinderError
+		if !errors.As(err, &fe) {
+			t.Fatalf("ToMap() unexpected error: %v", err)
+		}
+		if d := cmp.Diff(fe.Paths(), []string{`a\.b.c`}); d != "" {
+			t.Errorf("ToMap() unexpected error paths. (-got +want)\n%s\nerror: %v", d, err)
+		}
+		if !strings.Contains(err.Error(), "output key a.b.c is duplicated") {
+			t.Errorf("ToMap() unexpected error: %v", err)
+		}
+	})
+
+	t.Run("ToStruct", func(t *testing.T) {
+		f, err := NewFinderWithSep(map[string]interface{}{"Child_1": map[string]interface{}{"ID": 2}}, "_")
+		if err != nil {
+			t.Fatalf("NewFinderWithSep() unexpected error [%v] occured.", err)
+		}
+
+		got, _, err := f.Query(`Child\_1_ID`).ToStruct()
+		if err != nil {
+			t.Fatalf("ToStruct() unexpected error [%v] occured.", err)
+		}
+
+		b, err := json.Marshal(got)
+		if err != nil {
+			t.Fatalf("json.Marshal() unexpected error [%v] occured.", err)
+		}
+		if d := cmp.Diff(string(b), `{"Child_1":{"ID":2}}`); d != "" {
+			t.Errorf("ToStruct() unexpected result. (-got +want)\n%s", d)
+		}
+	})
+}
+
+func TestFinderErrorIs(t *testing.T) {
+	t.Parallel()
+
+	f, err := NewFinder(newFinderTestStructPtr())
+	if err != nil {
+		t.Fatalf("NewFinder() unexpected error [%v] occured.", err)
+	}
+
+	_, err = f.Into("FinderTestStruct2").Find("NonExist").ToMap()
+	if !errors.Is(err, ErrFieldNotFound) {
+		t.Errorf("errors.Is() is false. err: %v", err)
+	}
+
+	var fe *FieldError
+	if !errors.As(err, &fe) || fe.Name != "NonExist" {
+		t.Errorf("errors.As() unexpected result. err: %v", err)
+	}
+
+	err = f.Reset().SetPath("Int64", "abc")
+	if !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("errors.Is() is false. err: %v", err)
+	}
+}
+
+func TestFindOptional(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		chain           func(*Finder) *Finder
+		wantMap         map[string]interface{}
+		wantErrorString string
+	}{
+		{
+			name: "with required and optional fields",
+			chain: func(f *Finder) *Finder {
+				return f.Find("String").FindOptional("NonExist", "Int64")
+			},
+			wantMap: map[string]interface{}{
+				"String": "test name",
+				"Int64":  int64(-1),
+			},
+		},
+		{
+			name: "with default",
+			chain: func(f *Finder) *Finder {
+				return f.FindWithDefault("NonExist", "def").FindWithDefault("String", "def")
+			},
+			wantMap: map[string]interface{}{
+				"NonExist": "def",
+				"String":   "test name",
+			},
+		},
+		{
+			name: "with optional fields in missing nested structs",
+			chain: func(f *Finder) *Finder {
+				return f.
+					Into("NonExist").FindOptional("String").FindWithDefault("Int", 1).
+					Into("FinderTestStruct2", "NonExist", "Deep").FindOptional("String").
+					Into("FinderTestStruct2").Find("String")
+			},
+			wantMap: map[string]interface{}{
+				"NonExist.Int":             1,
+				"FinderTestStruct2.String": "struct2 string",
+			},
+		},
+		{
+			name: "with optional fields in wildcards",
+			chain: func(f *Finder) *Finder {
+				return f.Into("FinderTestStruct4Slice[*]").FindOptional("String", "NonExist")
+			},
+			wantMap: map[string]interface{}{
+				"FinderTestStruct4Slice[*].String": []interface{}{"key100", "key200"},
+			},
+		},
+		{
+			name: "with optional elements of slice and map",
+			chain: func(f *Finder) *Finder {
+				return f.
+					FindOptional(`Map["nokey"]`, `Map["k1"]`).FindWithDefault("Stringslice[3]", "d").
+					Into("FinderTestStruct4Slice[9]").FindOptional("String")
+			},
+			wantMap: map[string]interface{}{
+				`Map["k1"]`:      "v1",
+				"Stringslice[3]": "d",
+			},
+		},
+		{
+			name: "with required element of slice",
+			chain: func(f *Finder) *Finder {
+				return f.FindOptional(`Map["nokey"]`).Find("Stringslice[3]")
+			},
+			wantErrorString: "index 3 is out of range of Stringslice (len 2)",
+		},
+		{
+			name: "with required field in missing nested struct",
+			chain: func(f *Finder) *Finder {
+				return f.Into("NonExist").Find("String").FindOptional("Int")
+			},
+			wantErrorString: "Error in name: NonExist, key: NonExist. [field name NonExist does not exist]",
+		},
+		{
+			name: "with Find that replaces optional fields",
+			chain: func(f *Finder) *Finder {
+				return f.FindOptional("NonExist").Find("NonExist")
+			},
+			wantErrorString: "field name NonExist does not exist",
+		},
+		{
+			name: "with missing nested struct that does not have names",
+			chain: func(f *Finder) *Finder {
+				return f.Into("NonExist")
+			},
+			wantErrorString: "Error in name: NonExist, key: NonExist. [field name NonExist does not exist]",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := NewFinder(newFinderTestStructPtr())
+			if err != nil {
+				t.Fatalf("NewFinder() unexpected error [%v] occured.", err)
+			}
+
+			got, err := tt.chain(f).ToMap()
+			if tt.wantErrorString != "" {
+				if err == nil || err.Error() != tt.wantErrorString {
+					t.Errorf("ToMap() unexpected error. got: %v, want: %s", err, tt.wantErrorString)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ToMap() unexpected error [%v] occured.", err)
+			}
+			if d := cmp.Diff(got, tt.wantMap); d != "" {
+				t.Errorf("ToMap() unexpected result. (-got +want)\n%s", d)
+			}
+		})
+	}
+}
+
+func TestFindOptionalWithNilStruct(t *testing.T) {
+	t.Parallel()
+
+	newFinder := func(t *testing.T) *Finder {
+		f, err := NewFinder(&FinderTestStruct{String: "name"})
+		if err != nil {
+			t.Fatalf("NewFinder() unexpected error [%v] occured.", err)
+		}
+		return f
+	}
+
+	got, err := newFinder(t).Find("String").Into("FinderTestStruct2Ptr").FindOptional("String").FindWithDefault("FinderTestStruct3", "def").ToMap()
+	if err != nil {
+		t.Fatalf("ToMap() unexpected error [%v] occured.", err)
+	}
+	want := map[string]interface{}{
+		"String":                                 "name",
+		"FinderTestStruct2Ptr.FinderTestStruct3": "def",
+	}
+	if d := cmp.Diff(got, want); d != "" {
+		t.Errorf("ToMap() unexpected result. (-got +want)\n%s", d)
+	}
+
+	fks, err := NewFinderKeysFromBytes([]byte("Keys:\n  - String\n  - FinderTestStruct2Ptr.String:\n      optional: true\n"), "yaml")
+	if err != nil {
+		t.Fatalf("NewFinderKeysFromBytes() unexpected error [%v] occured.", err)
+	}
+	got, err = newFinder(t).FromKeys(fks).ToMap()
+	if err != nil {
+		t.Fatalf("FromKeys().ToMap() unexpected error [%v] occured.", err)
+	}
+	if d := cmp.Diff(got, map[string]interface{}{"String": "name"}); d != "" {
+		t.Errorf("FromKeys().ToMap() unexpected result. (-got +want)\n%s", d)
+	}
+
+	i, _, err := newFinder(t).Find("String").Into("FinderTestStruct2Ptr").FindOptional("String").ToStruct()
+	if err != nil {
+		t.Fatalf("ToStruct() unexpected error [%v] occured.", err)
+	}
+	b, err := json.Marshal(i)
+	if err != nil {
+		t.Fatalf("json.Marshal() unexpected error [%v] occured.", err)
+	}
+	if d := cmp.Diff(string(b), `{"String":"name"}`); d != "" {
+		t.Errorf("ToStruct() unexpected result. (-got +want)\n%s", d)
+	}
+
+	// required fields in nil nested structs are not found
+	_, err = newFinder(t).Into("FinderTestStruct2Ptr").Find("String").ToMap()
+	if !errors.Is(err, ErrFieldNotFound) {
+		t.Errorf("ToMap() unexpected error: %v", err)
+	}
+}
+
+func TestFinderErrorWithMultiplePaths(t *testing.T) {
+	t.Parallel()
+
+	f, err := NewFinder(newFinderTestStructPtr())
+	if err != nil {
+		t.Fatalf("NewFinder() unexpected error [%v] occured.", err)
+	}
+
+	err = f.Set(map[string]interface{}{
+		"String":    1,
+		"NonExist":  1,
+		"Int64":     "abc",
+		"Stringptr": nil,
+	})
+
+	var fe *FinderError
+	if !errors.As(err, &fe) {
+		t.Fatalf("Set() unexpected error type: %T", err)
+	}
+	if d := cmp.Diff(fe.Paths(), []string{"Int64", "NonExist", "String"}); d != "" {
+		t.Errorf("Paths() unexpected result. (-got +want)\n%s", d)
+	}
+
+	want := strings.Join([]string{
+		"Error in name: Int64, key: Int64. [field Int64: type mismatch. expected: int64, actual: string]",
+		"Error in name: NonExist, key: NonExist. [field name NonExist does not exist]",
+		"Error in name: String, key: String. [field String: type mismatch. expected: string, actual: int]",
+	}, "\n")
+	for i := 0; i < 5; i++ {
+		if err.Error() != want || f.Error() != want {
+			t.Fatalf("Error() unexpected result. got: %s, want: %s", err.Error(), want)
+		}
+	}
+
+	// FinderError is a snapshot of errors
+	f.Reset()
+	if f.Err() != nil || len(fe.Paths()) != 3 {
+		t.Errorf("unexpected errors after Reset(). Err(): %v, FinderError: %v", f.Err(), fe)
+	}
+}
+
+func TestFinderErrorWithMultipleMissingFields(t *testing.T) {
+	t.Parallel()
+
+	f, err := NewFinder(newFinderTestStructPtr())
+	if err != nil {
+		t.Fatalf("NewFinder() unexpected error [%v] occured.", err)
+	}
+
+	_, err = f.Find("Missing1", "String", "Missing2").ToMap()
+
+	var fe *FinderError
+	if !errors.As(err, &fe) {
+		t.Fatalf("ToMap() unexpected error type: %T", err)
+	}
+	if d := cmp.Diff(fe.Paths(), []string{"Missing1", "Missing2"}); d != "" {
+		t.Errorf("Paths() unexpected result. (-got +want)\n%s", d)
+	}
+}
+
+func TestFinderWithMap(t *testing.T) {
+	t.Parallel()
+
+	m := map[string]interface{}{
+		"Name": "top",
+		"Company": map[string]interface{}{
+			"Name": "company",
+			"Groups": []interface{}{
+				map[string]interface{}{"Name": "g1"},
+				map[string]interface{}{"Name": "g2"},
+			},
+		},
+		"Struct": &FinderTestStruct4{String: "s"},
+	}
+
+	f, err := NewFinder(m)
+	if err != nil {
+		t.Fatalf("NewFinder() unexpected error [%v] occured.", err)
+	}
+
+	got, err := f.
+		Find("Name").
+		Into("Company").Find("Name", "Groups[1]").
+		Into("Company", "Groups[*]").Find("Name").
+		Into("Struct").Find("String").
+		ToMap()
+	if err != nil {
+		t.Fatalf("ToMap() unexpected error [%v] occured.", err)
+	}
+
+	want := map[string]interface{}{
+		"Name":                   "top",
+		"Company.Name":           "company",
+		"Company.Groups[1]":      map[string]interface{}{"Name": "g2"},
+		"Company.Groups[*].Name": []interface{}{"g1", "g2"},
+		"Struct.String":          "s",
+	}
+	if d := cmp.Diff(got, want); d != "" {
+		t.Errorf("ToMap() unexpected result. (-got +want)\n%s", d)
+	}
+
+	err = f.Set(map[string]interface{}{
+		"Company.Name":   "new company",
+		"Company.Added":  1,
+		"Struct.String2": "s2",
+	})
+	if err != nil {
+		t.Fatalf("Set() unexpected error [%v] occured.", err)
+	}
+	company := m["Company"].(map[string]interface{})
+	if company["Name"] != "new company" || company["Added"] != 1 || m["Struct"].(*FinderTestStruct4).String2 != "s2" {
+		t.Errorf("Set() did not set values. got: %v", m)
+	}
+
+	if err := f.Reset().SetPath(`Company.Groups[0].Name`, "x"); err != nil {
+		t.Errorf("SetPath() unexpected error [%v] occured.", err)
+	}
+	if err := f.Reset().SetPath(`Company["Labels"]`, "x"); err != nil {
+		t.Errorf("SetPath() unexpected error [%v] occured.", err)
+	}
+	group := company["Groups"].([]interface{})[0].(map[string]interface{})
+	if group["Name"] != "x" || company["Labels"] != "x" {
+		t.Errorf("SetPath() did not set values. got: %v", m)
+	}
+	if err := f.Reset().SetPath("NotExist.Name", "x"); !errors.Is(err, ErrFieldNotFound) {
+		t.Errorf("SetPath() unexpected error: %v", err)
+	}
+}
+
+func TestFinderWithTag(t *testing.T) {
+	t.Parallel()
+
+	type (
+		tagUser struct {
+			ID   int    `json:"user_id"`
+			Name string `json:"name,omitempty"`
+		}
+		tagGroup struct {
+			Name   string    `json:"group_name"`
+			Secret string    `json:"-"`
+			Users  []tagUser `json:"users"`
+			Leader *tagUser  `json:"leader"`
+		}
+	)
+
+	gr := &tagGroup{
+		Name:   "group",
+		Secret: "secret",
+		Users:  []tagUser{{ID: 1, Name: "u1"}, {ID: 2, Name: "u2"}},
+	}
+
+	g, err := NewGetterWithTag(gr, "json")
+	if err != nil {
+		t.Fatalf("NewGetterWithTag() unexpected error [%v] occured.", err)
+	}
+	f, err := NewFinderWithGetter(g)
+	if err != nil {
+		t.Fatalf("NewFinderWithGetter() unexpected error [%v] occured.", err)
+	}
+
+	if err := f.SetPath("leader.name", "boss"); err != nil {
+		t.Fatalf("SetPath() unexpected error [%v] occured.", err)
+	}
+	if gr.Leader == nil || gr.Leader.Name != "boss" {
+		t.Errorf("SetPath() did not set the field. got: %+v", gr.Leader)
+	}
+
+	got, err := f.
+		Find("group_name", "Secret").
+		Into("users[1]").Find("user_id").
+		Into("users", "*").Find("name").
+		ToMap()
+	if err == nil {
+		t.Fatalf("ToMap() error did not occur. got: %v", got)
+	}
+	if !strings.Contains(err.Error(), "Secret") {
+		t.Errorf("ToMap() unexpected error: %v", err)
+	}
+
+	got, err = f.Reset().
+		Find("group_name").
+		Into("users[1]").Find("user_id").
+		Into("users", "*").Find("name").
+		Into("leader").Find("name").
+		ToMap()
+	if err != nil {
+		t.Fatalf("ToMap() unexpected error [%v] occured.", err)
+	}
+
+	want := map[string]interface{}{
+		"group_name":       "group",
+		"users[1].user_id": 2,
+		"users[*].name":    []interface{}{"u1", "u2"},
+		"leader.name":      "boss",
+	}
+	if d := cmp.Diff(got, want); d != "" {
+		t.Errorf("ToMap() unexpected result. (-got +want)\n%s", d)
+	}
+}
+
 func TestFromKeys(t *testing.T) {
+	t.Parallel()
+
 	var f *Finder
 	var fk *FinderKeys
 	var err error
@@ -577,7 +1951,7 @@ func TestFromKeys(t *testing.T) {
 				chain: fs[3].FromKeys(fks[3]),
 			},
 			wantError:       true,
-			wantErrorString: "Error in name: NonExist, key: NonExist. [name NonExist does not exist]",
+			wantErrorString: "Error in name: NonExist, key: NonExist. [field name NonExist does not exist]",
 		},
 		{
 			name: "with Struct with existed name and Find with non-existed name",
@@ -635,6 +2009,205 @@ func TestFromKeys(t *testing.T) {
 	}
 }
 
+func TestFromKeysWithAccessors(t *testing.T) {
+	t.Parallel()
+
+	fks, err := NewFinderKeys("examples/finder_from_conf", "ex_test_accessor_yml")
+	if err != nil {
+		t.Fatalf("NewFinderKeys() error = %v", err)
+	}
+
+	f, err := NewFinder(newFinderTestStructPtr())
+	if err != nil {
+		t.Fatalf("NewFinder() error = %v", err)
+	}
+
+	got, err := f.FromKeys(fks).ToMap()
+	if err != nil {
+		t.Fatalf("ToMap() unexpected error = %v", err)
+	}
+
+	want := map[string]interface{}{
+		"Stringslice[1]":                    "strslice2",
+		`Map["k1"]`:                         "v1",
+		"FinderTestStruct4Slice[1].String":  "key200",
+		"FinderTestStruct4Slice[1].String2": "value200",
+		"FinderTestStruct4PtrSlice[0]":      FinderTestStruct4{String: "key991", String2: "value991"},
+	}
+	if d := cmp.Diff(got, want); d != "" {
+		t.Errorf("(-got +want)\n%s", d)
+	}
+}
+
+func TestFromKeysWithWildcards(t *testing.T) {
+	t.Parallel()
+
+	fks, err := NewFinderKeys("examples/finder_from_conf", "ex_test_wildcard_yml")
+	if err != nil {
+		t.Fatalf("NewFinderKeys() error = %v", err)
+	}
+
+	f, err := NewFinder(newFinderTestStructPtr())
+	if err != nil {
+		t.Fatalf("NewFinder() error = %v", err)
+	}
+
+	got, err := f.FromKeys(fks).ToMap()
+	if err != nil {
+		t.Fatalf("ToMap() unexpected error = %v", err)
+	}
+
+	want := map[string]interface{}{
+		"Stringslice[*]":                      []interface{}{"strslice1", "strslice2"},
+		"Map[*]":                              []interface{}{"v1", 2},
+		"FinderTestStruct4PtrSlice[*].String": []interface{}{"key991", "key992"},
+	}
+	if d := cmp.Diff(got, want); d != "" {
+		t.Errorf("(-got +want)\n%s", d)
+	}
+}
+
+func TestFromKeysWithPolicies(t *testing.T) {
+	t.Parallel()
+
+	fks, err := NewFinderKeys("examples/finder_from_conf", "ex_test_spec_yml")
+	if err != nil {
+		t.Fatalf("NewFinderKeys() error = %v", err)
+	}
+
+	f, err := NewFinder(newFinderTestStructPtr())
+	if err != nil {
+		t.Fatalf("NewFinder() error = %v", err)
+	}
+
+	got, err := f.FromKeys(fks).ToMap()
+	if err != nil {
+		t.Fatalf("ToMap() unexpected error = %v", err)
+	}
+
+	want := map[string]interface{}{
+		"String":                        "test name",
+		"NonExistDefault":               "def",
+		"Int64":                         int64(-1),
+		"FinderTestStruct2Ptr.String":   "struct2 string ptr",
+		"FinderTestStruct2Ptr.NonExist": 1,
+		"NonExistStruct.Ints":           []interface{}{1, 2},
+	}
+	if d := cmp.Diff(got, want); d != "" {
+		t.Errorf("(-got +want)\n%s", d)
+	}
+}
+
+func TestFromKeysWithAliasesAndTransforms(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		conf            string
+		wantMap         map[string]interface{}
+		wantErrorString string
+	}{
+		{
+			name: "with aliases and transforms",
+			conf: `
+Keys:
+  - String:
+      alias: name
+      transform: upper
+  - FinderTestStruct2Ptr:
+    - FinderTestStruct3:
+      - Int:
+          alias: number
+          transform: string
+  - FinderTestStruct4Slice[*]:
+    - String:
+        alias: keys
+        transform: upper
+  - NonExist:
+      alias: country
+      default: JP
+      transform: lower
+  - Int64
+`,
+			wantMap: map[string]interface{}{
+				"name":    "TEST NAME",
+				"number":  "-456",
+				"keys":    []interface{}{"KEY100", "KEY200"},
+				"country": "JP",
+				"Int64":   int64(-1),
+			},
+		},
+		{
+			name: "with default of map",
+			conf: `
+Keys:
+  - NonExist:
+      alias: labels
+      default:
+        env: dev
+        tags: [a, {k: v}]
+`,
+			wantMap: map[string]interface{}{
+				"labels": map[string]interface{}{
+					"env":  "dev",
+					"tags": []interface{}{"a", map[string]interface{}{"k": "v"}},
+				},
+			},
+		},
+		{
+			name: "with duplicated aliases",
+			conf: `
+Keys:
+  - String:
+      alias: Int64
+  - Int64
+`,
+			wantErrorString: "Error in key: String. [output key Int64 is duplicated with key Int64]",
+		},
+		{
+			name: "with transform for unsupported type",
+			conf: `
+Keys:
+  - Int64:
+      transform: upper
+`,
+			wantErrorString: "Error in key: Int64. [transform upper: int64 is not string]",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fks, err := NewFinderKeysFromBytes([]byte(tt.conf), "yaml")
+			if err != nil {
+				t.Fatalf("NewFinderKeysFromBytes() unexpected error [%v] occured.", err)
+			}
+
+			f, err := NewFinder(newFinderTestStructPtr())
+			if err != nil {
+				t.Fatalf("NewFinder() unexpected error [%v] occured.", err)
+			}
+
+			got, err := f.FromKeys(fks).ToMap()
+			if tt.wantErrorString != "" {
+				if err == nil || err.Error() != tt.wantErrorString {
+					t.Errorf("ToMap() unexpected error. got: %v, want: %s", err, tt.wantErrorString)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ToMap() unexpected error [%v] occured.", err)
+			}
+			if d := cmp.Diff(got, tt.wantMap); d != "" {
+				t.Errorf("ToMap() unexpected result. (-got +want)\n%s", d)
+			}
+		})
+	}
+}
+
 func TestNewFinderKeys(t *testing.T) {
 	t.Parallel()
 
@@ -695,6 +2268,40 @@ func TestNewFinderKeys(t *testing.T) {
 				"FinderTestStruct2Ptr.FinderTestStruct3.Int",
 			},
 		},
+		{
+			name:      "with valid yaml file with accessors",
+			args:      args{d: "examples/finder_from_conf", n: "ex_test_accessor_yml"},
+			wantError: false,
+			wantLen:   5,
+			wantKeys: []string{
+				"Stringslice[1]",
+				`Map["k1"]`,
+				"FinderTestStruct4Slice[1].String",
+				"FinderTestStruct4Slice[1].String2",
+				"FinderTestStruct4PtrSlice[0]",
+			},
+		},
+		{
+			name:      "with valid yaml file with policies",
+			args:      args{d: "examples/finder_from_conf", n: "ex_test_spec_yml"},
+			wantError: false,
+			wantLen:   8,
+			wantKeys: []string{
+				"String",
+				"NonExist",
+				"NonExistDefault",
+				"Int64",
+				"FinderTestStruct2Ptr.String",
+				"FinderTestStruct2Ptr.NonExist",
+				"NonExistStruct.String",
+				"NonExistStruct.Ints",
+			},
+		},
+		{
+			name:      "with invalid policy",
+			args:      args{d: "examples/finder_from_conf", n: "ex_test_invalid_spec_yml"},
+			wantError: true,
+		},
 		{
 			name:      "with invalid conf file that Keys does not exist",
 			args:      args{d: "examples/finder_from_conf", n: "ex_test_nonkeys_yml"},
@@ -742,6 +2349,227 @@ func TestNewFinderKeys(t *testing.T) {
 	}
 }
 
+func TestNewFinderKeysFromReader(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		newFks    func() (*FinderKeys, error)
+		wantError bool
+		wantKeys  []string
+	}{
+		{
+			name: "with yaml bytes",
+			newFks: func() (*FinderKeys, error) {
+				return NewFinderKeysFromBytes([]byte("Keys:\n  - String\n  - FinderTestStruct2:\n    - String\n"), "yaml")
+			},
+			wantKeys: []string{"String", "FinderTestStruct2.String"},
+		},
+		{
+			name: "with json reader",
+			newFks: func() (*FinderKeys, error) {
+				return NewFinderKeysFromReader(strings.NewReader(`{"Keys": ["String", {"NonExist": {"optional": true}}]}`), "JSON")
+			},
+			wantKeys: []string{"String", "NonExist"},
+		},
+		{
+			name: "with file reader",
+			newFks: func() (*FinderKeys, error) {
+				r, err := os.Open("examples/finder_from_conf/ex_test_accessor_yml.yml")
+				if err != nil {
+					return nil, err
+				}
+				defer r.Close()
+				return NewFinderKeysFromReader(r, "yml")
+			},
+			wantKeys: []string{
+				"Stringslice[1]",
+				`Map["k1"]`,
+				"FinderTestStruct4Slice[1].String",
+				"FinderTestStruct4Slice[1].String2",
+				"FinderTestStruct4PtrSlice[0]",
+			},
+		},
+		{
+			name: "with unsupported format",
+			newFks: func() (*FinderKeys, error) {
+				return NewFinderKeysFromBytes([]byte("Keys:\n  - String\n"), "xml")
+			},
+			wantError: true,
+		},
+		{
+			name: "with invalid content",
+			newFks: func() (*FinderKeys, error) {
+				return NewFinderKeysFromBytes([]byte(`{"Keys": [`), "json")
+			},
+			wantError: true,
+		},
+		{
+			name: "with yaml maps that have multiple keys",
+			newFks: func() (*FinderKeys, error) {
+				return NewFinderKeysFromBytes([]byte(`
+keys:
+  - String
+  - FinderTestStruct2Ptr:
+      String:
+        alias: s2
+      FinderTestStruct3: [Int, String]
+    Int64:
+      optional: true
+`), "yaml")
+			},
+			wantKeys: []string{
+				"String",
+				"FinderTestStruct2Ptr.String",
+				"FinderTestStruct2Ptr.FinderTestStruct3.Int",
+				"FinderTestStruct2Ptr.FinderTestStruct3.String",
+				"Int64",
+			},
+		},
+		{
+			name: "with json objects that have multiple keys",
+			newFks: func() (*FinderKeys, error) {
+				return NewFinderKeysFromBytes([]byte(`{"Keys": [
+					{"Zeta": ["B", "A"], "Alpha": {"C": {"optional": true}, "B": "A"}},
+					"Mid"
+				]}`), "json")
+			},
+			wantKeys: []string{"Zeta.B", "Zeta.A", "Alpha.C", "Alpha.B.A", "Mid"},
+		},
+		{
+			name: "with duplicated keys",
+			newFks: func() (*FinderKeys, error) {
+				return NewFinderKeysFromBytes([]byte("Keys:\n  - String\n  - Int64\n  - String\n"), "yaml")
+			},
+			wantError: true,
+		},
+		{
+			name: "with duplicated paths by braces",
+			newFks: func() (*FinderKeys, error) {
+				return NewFinderKeysFromBytes([]byte("Keys:\n  - A.{B,C}\n  - A:\n    - C\n"), "yaml")
+			},
+			wantError: true,
+		},
+		{
+			name: "with duplicated Keys",
+			newFks: func() (*FinderKeys, error) {
+				return NewFinderKeysFromBytes([]byte(`{"Keys": ["A"], "keys": ["B"]}`), "json")
+			},
+			wantError: true,
+		},
+		{
+			name: "with ambiguous map",
+			newFks: func() (*FinderKeys, error) {
+				return NewFinderKeysFromBytes([]byte("Keys:\n  - A:\n      alias: x\n      B: [C]\n"), "yaml")
+			},
+			wantError: true,
+		},
+		{
+			name: "with duplicated specification",
+			newFks: func() (*FinderKeys, error) {
+				return NewFinderKeysFromBytes([]byte(`{"Keys": [{"A": {"alias": "x", "alias": "y"}}]}`), "json")
+			},
+			wantError: true,
+		},
+		{
+			name: "with empty map",
+			newFks: func() (*FinderKeys, error) {
+				return NewFinderKeysFromBytes([]byte(`{"Keys": [{"A": {}}]}`), "json")
+			},
+			wantError: true,
+		},
+		{
+			name: "with json that has data after top-level value",
+			newFks: func() (*FinderKeys, error) {
+				return NewFinderKeysFromBytes([]byte(`{"Keys": ["A"]} {}`), "json")
+			},
+			wantError: true,
+		},
+		{
+			name: "with transform that is not registered",
+			newFks: func() (*FinderKeys, error) {
+				return NewFinderKeysFromBytes([]byte("Keys:\n  - String:\n      transform: unknown\n"), "yaml")
+			},
+			wantError: true,
+		},
+		{
+			name: "with alias that is not string",
+			newFks: func() (*FinderKeys, error) {
+				return NewFinderKeysFromBytes([]byte("Keys:\n  - String:\n      alias: [a]\n"), "yaml")
+			},
+			wantError: true,
+		},
+		{
+			name: "with content that Keys does not exist",
+			newFks: func() (*FinderKeys, error) {
+				return NewFinderKeysFromBytes([]byte("Names:\n  - String\n"), "yaml")
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.newFks()
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("NewFinderKeysFromReader() error did not occur. got: %v", got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NewFinderKeysFromReader() unexpected error [%v] occured.", err)
+			}
+			if d := cmp.Diff(got.Keys(), tt.wantKeys); d != "" {
+				t.Errorf("NewFinderKeysFromReader() unexpected keys. (-got +want)\n%s", d)
+			}
+		})
+	}
+}
+
+func TestNewFinderKeysConcurrently(t *testing.T) {
+	t.Parallel()
+
+	const n = 10
+
+	var wg sync.WaitGroup
+	errs := make([]error, n)
+	lens := make([]int, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			var fks *FinderKeys
+			var err error
+			if i%2 == 0 {
+				fks, err = NewFinderKeys("examples/finder_from_conf", "ex_test1_yml")
+			} else {
+				fks, err = NewFinderKeysFromBytes([]byte(fmt.Sprintf(`{"Keys": ["K%d"]}`, i)), "json")
+			}
+			errs[i] = err
+			if err == nil {
+				lens[i] = fks.Len()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < n; i++ {
+		want := 1
+		if i%2 == 0 {
+			want = 15
+		}
+		if errs[i] != nil || lens[i] != want {
+			t.Errorf("unexpected result of #%d. err: %v, len: %d, want len: %d", i, errs[i], lens[i], want)
+		}
+	}
+}
+
 // benchmark tests
 
 func BenchmarkNewFinder_Val(b *testing.B) {
@@ -3,30 +3,92 @@ package structil
 import (
 	"fmt"
 	"reflect"
+	"sort"
+	"sync"
 	"unsafe"
 
 	"github.com/goldeneggg/structil/util"
 )
 
 // Getter is the struct that wraps the basic Getter method..
+// Getter can also wrap a map that has string keys. Then map keys are treated as field names.
 type Getter struct {
-	rv     reflect.Value            // Value of input interface
-	numf   int                      // Field nums
-	names  []string                 // Field names
-	hases  map[string]bool          // Field existing condition map of struct fields
-	types  map[string]reflect.Type  // Type map of struct fields
-	values map[string]reflect.Value // Value map of indirected struct fields
-	intfs  map[string]interface{}   // interface map of struct fields
-	cached map[string]bool
+	rv    reflect.Value          // Value of input interface
+	numf  int                    // Field nums
+	ti    *typeInfo              // Field informations shared by the same struct type. This is nil for map
+	opt   *GetterOption          // Options
+	mu    *sync.RWMutex          // Lock for cache. This is nil if Concurrent option is false
+	cache map[string]*fieldCache // Cache of struct fields
+}
+
+// GetterOption is the option for Getter.
+type GetterOption struct {
+	// Concurrent makes Getter safe for concurrent use by multiple goroutines.
+	Concurrent bool
+
+	// Tag is the struct tag key (e.g. "json") used to name fields.
+	// Fields are named by the tag value without options such as "omitempty", and fields tagged with "-" are ignored.
+	// Fields that do not have the tag are named by the field names.
+	Tag string
+
+	// Unexported enables to read unexported fields using unsafe.
+	// If the Getter is created from a struct value (not pointer), unexported fields are read from the copy of it.
+	Unexported bool
+
+	// FlattenEmbedded makes Names return promoted fields instead of embedded struct fields.
+	FlattenEmbedded bool
+}
+
+// FieldInfo is the metadata of a field reachable from the original struct.
+type FieldInfo struct {
+	Name      string            // Name used for lookups. This is the tag name if the Getter uses a struct tag
+	Index     []int             // Index sequence for reflect.Value.FieldByIndex
+	Depth     int               // Embedding depth. This is 0 for top level fields
+	Exported  bool              // Whether the field is exported
+	Anonymous bool              // Whether the field is an embedded field
+	Tag       reflect.StructTag // Tag of the field
+	Type      reflect.Type      // Type of the field
+}
+
+// fieldCache has cached informations of a struct field.
+type fieldCache struct {
+	has   bool          // Field existing condition
+	typ   reflect.Type  // Type of the field
+	raw   reflect.Value // Value of the field
+	value reflect.Value // Value of the indirected field
+	intf  interface{}   // interface of the field
 }
 
 // NewGetter returns a concrete Getter that uses and obtains from i.
-// i must be a struct or struct pointer.
+// i must be a struct, struct pointer or map that has string keys (e.g. map[string]interface{}).
 func NewGetter(i interface{}) (*Getter, error) {
-	rv := reflect.ValueOf(i)
+	return newGetter(reflect.ValueOf(i), nil)
+}
+
+// NewGetterWithOption returns a concrete Getter that uses and obtains from i with opt.
+// i must be a struct, struct pointer or map that has string keys.
+func NewGetterWithOption(i interface{}, opt *GetterOption) (*Getter, error) {
+	return newGetter(reflect.ValueOf(i), opt)
+}
+
+// NewGetterWithTag returns a concrete Getter that uses and obtains from i.
+// Fields are looked up by the names in the struct tag such as "json" and "yaml".
+// i must be a struct or struct pointer.
+func NewGetterWithTag(i interface{}, tag string) (*Getter, error) {
+	return newGetter(reflect.ValueOf(i), &GetterOption{Tag: tag})
+}
+
+// newGetter returns a concrete Getter that uses and obtains from rv.
+// If rv is addressable, the Getter refers to the original struct directly instead of its copy.
+func newGetter(rv reflect.Value, opt *GetterOption) (*Getter, error) {
+	if rv.Kind() == reflect.Interface {
+		rv = rv.Elem()
+	}
+
 	kind := rv.Kind()
+	i := util.ToI(rv)
 
-	if kind != reflect.Ptr && kind != reflect.Struct {
+	if kind != reflect.Ptr && kind != reflect.Struct && kind != reflect.Map {
 		return nil, fmt.Errorf("%+v is not supported kind: %v. value: %+v", i, kind, rv)
 	}
 
@@ -38,92 +100,292 @@ func NewGetter(i interface{}) (*Getter, error) {
 		return nil, fmt.Errorf("%+v is invalid argument. value: %+v", i, rv)
 	}
 
-	numf := rv.NumField()
+	if opt == nil {
+		opt = &GetterOption{}
+	}
 
-	return &Getter{
-		rv:     rv,
-		numf:   numf,
-		names:  make([]string, 0, numf),
-		hases:  map[string]bool{},
-		values: map[string]reflect.Value{},
-		types:  map[string]reflect.Type{},
-		intfs:  map[string]interface{}{},
-		cached: map[string]bool{},
-	}, nil
+	if rv.Kind() == reflect.Map {
+		return newMapGetter(rv, opt)
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%+v is not supported kind: %v. value: %+v", i, rv.Kind(), rv)
+	}
+
+	// unexported fields can be read only from addressable struct
+	if opt.Unexported && !rv.CanAddr() && rv.CanInterface() {
+		crv := reflect.New(rv.Type()).Elem()
+		crv.Set(rv)
+		rv = crv
+	}
+
+	g := &Getter{
+		rv:    rv,
+		numf:  rv.NumField(),
+		ti:    typeInfoOf(rv.Type(), opt.Tag),
+		opt:   opt,
+		cache: map[string]*fieldCache{},
+	}
+	if opt.Concurrent {
+		g.mu = &sync.RWMutex{}
+	}
+
+	return g, nil
+}
+
+// newMapGetter returns a concrete Getter that uses and obtains from map rv.
+func newMapGetter(rv reflect.Value, opt *GetterOption) (*Getter, error) {
+	if rv.Type().Key().Kind() != reflect.String {
+		return nil, fmt.Errorf("%+v is not supported map key kind: %v", util.ToI(rv), rv.Type().Key().Kind())
+	}
+
+	g := &Getter{
+		rv:    rv,
+		numf:  rv.Len(),
+		opt:   opt,
+		cache: map[string]*fieldCache{},
+	}
+	if opt.Concurrent {
+		g.mu = &sync.RWMutex{}
+	}
+
+	return g, nil
+}
+
+// newChild returns a Getter for rv that is a nested struct in g.
+// The returned Getter inherits the options of g.
+func (g *Getter) newChild(rv reflect.Value) (*Getter, error) {
+	return newGetter(rv, g.opt)
 }
 
 // NumField returns num of struct field.
+// If the Getter wraps a map, this returns num of map keys.
 func (g *Getter) NumField() int {
 	return g.numf
 }
 
 // Has tests whether the original struct has a field named name arg.
 func (g *Getter) Has(name string) bool {
-	if _, ok := g.cached[name]; !ok {
-		g.cache(name)
+	return g.field(name).has
+}
+
+// field returns the cached informations of a field named name.
+func (g *Getter) field(name string) *fieldCache {
+	if g.mu == nil {
+		fc, ok := g.cache[name]
+		if !ok {
+			fc = g.newFieldCache(name)
+			g.cache[name] = fc
+		}
+		return fc
+	}
+
+	g.mu.RLock()
+	fc, ok := g.cache[name]
+	g.mu.RUnlock()
+	if ok {
+		return fc
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if fc, ok = g.cache[name]; !ok {
+		fc = g.newFieldCache(name)
+		g.cache[name] = fc
+	}
+	return fc
+}
+
+func (g *Getter) newFieldCache(name string) *fieldCache {
+	if g.ti == nil {
+		return g.newMapFieldCache(name)
+	}
+
+	fi, ok := g.ti.fields[name]
+	if !ok {
+		return &fieldCache{}
+	}
+
+	// frv is invalid if the field is promoted through a nil embedded struct pointer
+	frv := fieldByIndex(g.rv, fi.index, false)
+	if g.opt.Unexported {
+		frv = unlock(frv)
 	}
+	irv := reflect.Indirect(frv)
 
-	return g.hases[name]
+	return &fieldCache{
+		has:   true,
+		typ:   fi.sf.Type,
+		raw:   frv,
+		value: irv,
+		intf:  util.ToI(irv),
+	}
+}
+
+// newMapFieldCache returns the cached informations of a map value of key name.
+// The type of the value is its dynamic type if the map value type is interface.
+func (g *Getter) newMapFieldCache(name string) *fieldCache {
+	mrv := g.rv.MapIndex(reflect.ValueOf(name).Convert(g.rv.Type().Key()))
+	if !mrv.IsValid() {
+		return &fieldCache{}
+	}
+
+	typ := mrv.Type()
+	if mrv.Kind() == reflect.Interface {
+		mrv = mrv.Elem()
+		if mrv.IsValid() {
+			typ = mrv.Type()
+		}
+	}
+	irv := reflect.Indirect(mrv)
+
+	return &fieldCache{
+		has:   true,
+		typ:   typ,
+		raw:   mrv,
+		value: irv,
+		intf:  util.ToI(irv),
+	}
 }
 
-func (g *Getter) cache(name string) {
-	frv := g.rv.FieldByName(name)
-	if frv.IsValid() {
-		g.types[name] = frv.Type()
-		g.hases[name] = true
-	} else {
-		g.types[name] = nil
-		g.hases[name] = false
+// mapKeys returns sorted keys of the map that the Getter wraps.
+func (g *Getter) mapKeys() []string {
+	keys := make([]string, g.rv.Len())
+	for i, k := range sortedMapKeys(g.rv) {
+		keys[i] = k.String()
 	}
 
-	frv = reflect.Indirect(frv)
-	g.values[name] = frv
-	g.intfs[name] = util.ToI(frv)
-	g.cached[name] = true
+	return keys
+}
+
+// clearCache discards cached field informations.
+// This must be called after the original struct was modified.
+func (g *Getter) clearCache() {
+	if g.mu != nil {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+	}
+
+	g.cache = map[string]*fieldCache{}
 }
 
 // Names returns names of struct field.
+// If FlattenEmbedded option is true, embedded struct fields are replaced with their promoted fields.
+// If the Getter wraps a map, this returns sorted map keys.
 func (g *Getter) Names() []string {
-	// to setup g.names is run only once
-	if g.numf > 0 && len(g.names) == 0 {
-		var sf reflect.StructField
-		for i := 0; i < g.numf; i++ {
-			sf = g.rv.Type().Field(i)
-			g.names = append(g.names, sf.Name)
+	if g.ti == nil {
+		return g.mapKeys()
+	}
+
+	src := g.ti.names
+	if g.opt.FlattenEmbedded {
+		src = g.ti.flatNames
+	}
+
+	names := make([]string, len(src))
+	copy(names, src)
+
+	return names
+}
+
+// Fields returns the metadata of all fields reachable from the original struct including promoted fields.
+// Fields are ordered by the declaration, and promoted fields follow their embedded struct field.
+// Fields hidden by the other field and ambiguous fields are not included.
+// If the Getter wraps a map, this returns map keys with types of the values.
+func (g *Getter) Fields() []FieldInfo {
+	if g.ti == nil {
+		keys := g.mapKeys()
+		res := make([]FieldInfo, len(keys))
+		for i, key := range keys {
+			res[i] = FieldInfo{Name: key, Exported: true, Type: g.field(key).typ}
+		}
+		return res
+	}
+
+	res := make([]FieldInfo, len(g.ti.list))
+	for i, fi := range g.ti.list {
+		index := make([]int, len(fi.index))
+		copy(index, fi.index)
+
+		res[i] = FieldInfo{
+			Name:      fi.name,
+			Index:     index,
+			Depth:     len(fi.index) - 1,
+			Exported:  fi.sf.PkgPath == "",
+			Anonymous: fi.sf.Anonymous,
+			Tag:       fi.sf.Tag,
+			Type:      fi.sf.Type,
 		}
 	}
 
-	return g.names
+	return res
 }
 
 // GetType returns the reflect.Type object of the original struct field named name.
 // 2nd return value will be false if the original struct does not have a "name" field.
 func (g *Getter) GetType(name string) (reflect.Type, bool) {
-	if _, ok := g.cached[name]; !ok {
-		g.cache(name)
-	}
-
-	return g.types[name], g.hases[name]
+	fc := g.field(name)
+	return fc.typ, fc.has
 }
 
 // GetValue returns the reflect.Value object of the original struct field named name.
 // 2nd return value will be false if the original struct does not have a "name" field.
 func (g *Getter) GetValue(name string) (reflect.Value, bool) {
-	if _, ok := g.cached[name]; !ok {
-		g.cache(name)
-	}
-
-	return g.values[name], g.hases[name]
+	fc := g.field(name)
+	return fc.value, fc.has
 }
 
 // Get returns the interface of the original struct field named name.
 // 2nd return value will be false if the original struct does not have a "name" field.
 func (g *Getter) Get(name string) (interface{}, bool) {
-	if _, ok := g.cached[name]; !ok {
-		g.cache(name)
+	fc := g.field(name)
+	return fc.intf, fc.has
+}
+
+// Lookup returns the interface of the original struct field named name.
+// Unlike Get, *FieldError wrapping ErrFieldNotFound is returned if the original struct does not have a "name" field.
+func (g *Getter) Lookup(name string) (interface{}, error) {
+	fc := g.field(name)
+	if !fc.has {
+		return nil, newFieldNotFoundError(name)
 	}
 
-	return g.intfs[name], g.hases[name]
+	return fc.intf, nil
+}
+
+// LookupAs stores the original struct field named name into the value pointed to by target.
+// target must be a non-nil pointer. The field is indirected as well as Get unless target points to the field type.
+// *FieldError wrapping ErrFieldNotFound is returned if the original struct does not have a "name" field,
+// and wrapping ErrTypeMismatch is returned if the field is not assignable to the value pointed to by target.
+func (g *Getter) LookupAs(name string, target interface{}) error {
+	trv := reflect.ValueOf(target)
+	if trv.Kind() != reflect.Ptr || trv.IsNil() {
+		return fmt.Errorf("target %+v must be a non-nil pointer", target)
+	}
+	trv = trv.Elem()
+
+	fc := g.field(name)
+	if !fc.has {
+		return newFieldNotFoundError(name)
+	}
+
+	frv := fc.value
+	if fc.typ.AssignableTo(trv.Type()) {
+		frv = fc.raw
+	}
+
+	if !frv.IsValid() {
+		return newTypeMismatchError(name, trv.Type(), nil)
+	}
+	if !frv.CanInterface() {
+		return &FieldError{Name: name, Expected: trv.Type(), Actual: frv.Type(), Err: errUnexported}
+	}
+	if !frv.Type().AssignableTo(trv.Type()) {
+		return newTypeMismatchError(name, trv.Type(), frv.Type())
+	}
+
+	trv.Set(frv)
+	return nil
 }
 
 // Bool returns the byte of the original struct field named name.
@@ -523,41 +785,158 @@ func (g *Getter) IsArray(name string) bool {
 }
 
 func (g *Getter) is(name string, exp reflect.Kind) bool {
-	if !g.Has(name) {
+	fc := g.field(name)
+	if !fc.has {
 		return false
 	}
 
-	return g.values[name].Kind() == exp
+	return fc.value.Kind() == exp
+}
+
+// Entry is a key and value pair of map.
+// Elements of map fields are wrapped in Getters of Entry by MapGet, FilterGet, ReduceGet, GroupByGet and SortByGet.
+type Entry struct {
+	Key   interface{}
+	Value interface{}
 }
 
 // MapGet returns the interface slice of mapped values of the original struct field named name.
+// The field must be a slice, array or map. Each element is wrapped in a Getter,
+// and elements of map are wrapped in Getters of Entry in the order of sorted keys.
 func (g *Getter) MapGet(name string, f func(int, *Getter) (interface{}, error)) ([]interface{}, error) {
-	if !g.IsSlice(name) {
-		return nil, fmt.Errorf("field %s does not exist or is not slice type", name)
+	egs, err := g.elemGetters(name)
+	if err != nil {
+		return nil, err
 	}
 
-	var vi reflect.Value
-	var eg *Getter
-	var err error
 	var r interface{}
+	res := make([]interface{}, len(egs))
 
-	srv, _ := g.GetValue(name)
-	res := make([]interface{}, srv.Len())
+	for i, eg := range egs {
+		r, err = f(i, eg)
+		if err != nil {
+			return nil, err
+		}
+
+		res[i] = r
+	}
 
-	for i := 0; i < srv.Len(); i++ {
-		vi = srv.Index(i)
-		eg, err = NewGetter(util.ToI(vi))
+	return res, nil
+}
+
+// FilterGet returns the Getters of elements of the original struct field named name that f returns true.
+// The field must be a slice, array or map as well as MapGet.
+func (g *Getter) FilterGet(name string, f func(int, *Getter) (bool, error)) ([]*Getter, error) {
+	egs, err := g.elemGetters(name)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*Getter, 0, len(egs))
+	for i, eg := range egs {
+		ok, err := f(i, eg)
 		if err != nil {
 			return nil, err
 		}
 
-		r, err = f(i, eg)
+		if ok {
+			res = append(res, eg)
+		}
+	}
+
+	return res, nil
+}
+
+// ReduceGet returns the value accumulated by f from init over elements of the original struct field named name.
+// The field must be a slice, array or map as well as MapGet.
+func (g *Getter) ReduceGet(name string, init interface{}, f func(interface{}, int, *Getter) (interface{}, error)) (interface{}, error) {
+	egs, err := g.elemGetters(name)
+	if err != nil {
+		return nil, err
+	}
+
+	acc := init
+	for i, eg := range egs {
+		acc, err = f(acc, i, eg)
 		if err != nil {
 			return nil, err
 		}
+	}
 
-		res[i] = r
+	return acc, nil
+}
+
+// GroupByGet returns the Getters of elements of the original struct field named name grouped by keys returned from f.
+// Keys must be comparable. The field must be a slice, array or map as well as MapGet.
+func (g *Getter) GroupByGet(name string, f func(int, *Getter) (interface{}, error)) (map[interface{}][]*Getter, error) {
+	egs, err := g.elemGetters(name)
+	if err != nil {
+		return nil, err
+	}
+
+	res := map[interface{}][]*Getter{}
+	for i, eg := range egs {
+		key, err := f(i, eg)
+		if err != nil {
+			return nil, err
+		}
+
+		if key != nil && !reflect.TypeOf(key).Comparable() {
+			return nil, fmt.Errorf("key %+v of element %d is not comparable", key, i)
+		}
+
+		res[key] = append(res[key], eg)
 	}
 
 	return res, nil
 }
+
+// SortByGet returns the Getters of elements of the original struct field named name sorted by less.
+// The sort is stable and the original field is not modified. The field must be a slice, array or map as well as MapGet.
+func (g *Getter) SortByGet(name string, less func(*Getter, *Getter) bool) ([]*Getter, error) {
+	egs, err := g.elemGetters(name)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(egs, func(i, j int) bool {
+		return less(egs[i], egs[j])
+	})
+
+	return egs, nil
+}
+
+// elemGetters returns the Getters of elements of the slice, array or map field named name.
+func (g *Getter) elemGetters(name string) ([]*Getter, error) {
+	v, ok := g.GetValue(name)
+	if !ok {
+		return nil, fmt.Errorf("field %s does not exist or is not slice, array or map type", name)
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		res := make([]*Getter, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			eg, err := g.newChild(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			res[i] = eg
+		}
+		return res, nil
+	case reflect.Map:
+		keys := sortedMapKeys(v)
+		res := make([]*Getter, len(keys))
+		for i, k := range keys {
+			e := &Entry{Key: util.ToI(k), Value: util.ToI(v.MapIndex(k))}
+			eg, err := g.newChild(reflect.ValueOf(e))
+			if err != nil {
+				return nil, err
+			}
+			res[i] = eg
+		}
+		return res, nil
+	default:
+		return nil, fmt.Errorf("field %s does not exist or is not slice, array or map type", name)
+	}
+}
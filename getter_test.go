@@ -1,9 +1,11 @@
 package structil_test
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"reflect"
+	"sync"
 	"testing"
 	"unsafe"
 
@@ -342,6 +344,482 @@ func TestNewGetter(t *testing.T) {
 	}
 }
 
+func TestNewGetterWithOption(t *testing.T) {
+	t.Parallel()
+
+	g, err := NewGetterWithOption(newGetterTestStructPtr(), &GetterOption{Concurrent: true})
+	if err != nil {
+		t.Fatalf("NewGetterWithOption() unexpected error [%v] occured.", err)
+	}
+
+	names := []string{"String", "Int64", "GetterTestStruct2", "GetterTestStruct3", "NotExist"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			name := names[i%len(names)]
+			g.Has(name)
+			g.Get(name)
+			g.GetType(name)
+			g.IsStruct(name)
+			g.Names()
+		}(i)
+	}
+	wg.Wait()
+
+	str, ok := g.String("String")
+	if !ok || str != "test name" {
+		t.Errorf("String() unexpected result. got: %v, ok: %v", str, ok)
+	}
+
+	// nested Getters inherit the option
+	res, err := g.MapGet("GetterTestStruct4PtrSlice", func(i int, eg *Getter) (interface{}, error) {
+		var wg sync.WaitGroup
+		for j := 0; j < 5; j++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				eg.Get("String")
+			}()
+		}
+		wg.Wait()
+
+		str, _ := eg.String("String2")
+		return str, nil
+	})
+	if err != nil {
+		t.Fatalf("MapGet() unexpected error [%v] occured.", err)
+	}
+	if d := cmp.Diff(res, []interface{}{"value991", "value992"}); d != "" {
+		t.Errorf("MapGet() unexpected result. (-got +want)\n%s", d)
+	}
+}
+
+func TestGetterPromotedFields(t *testing.T) {
+	t.Parallel()
+
+	type (
+		inner struct {
+			Deep string
+		}
+		left struct {
+			Dup string
+			*inner
+		}
+		right struct {
+			Dup string
+		}
+		outer struct {
+			left
+			right
+		}
+		embA struct {
+			*inner
+		}
+		embB struct {
+			*inner
+		}
+		double struct {
+			embA
+			embB
+		}
+	)
+
+	tests := []struct {
+		name    string
+		arg     interface{}
+		field   string
+		wantHas bool
+		want    interface{}
+	}{
+		{
+			name:    "promoted field through embedded struct pointer",
+			arg:     outer{left: left{inner: &inner{Deep: "deep"}}},
+			field:   "Deep",
+			wantHas: true,
+			want:    "deep",
+		},
+		{
+			name:    "promoted field through nil embedded struct pointer",
+			arg:     outer{},
+			field:   "Deep",
+			wantHas: true,
+			want:    nil,
+		},
+		{
+			name:    "fields of the same type embedded twice at the same depth",
+			arg:     double{embA: embA{inner: &inner{Deep: "a"}}, embB: embB{inner: &inner{Deep: "b"}}},
+			field:   "Deep",
+			wantHas: false,
+			want:    nil,
+		},
+		{
+			name:    "ambiguous fields at the same depth",
+			arg:     outer{left: left{Dup: "left"}, right: right{Dup: "right"}},
+			field:   "Dup",
+			wantHas: false,
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			g, err := NewGetter(tt.arg)
+			if err != nil {
+				t.Fatalf("NewGetter() unexpected error [%v] occured.", err)
+			}
+
+			got, has := g.Get(tt.field)
+			if has != tt.wantHas {
+				t.Errorf("Get() unexpected has. got: %v, want: %v", has, tt.wantHas)
+			}
+			if got != tt.want {
+				t.Errorf("Get() unexpected result. got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewGetterWithTag(t *testing.T) {
+	t.Parallel()
+
+	type (
+		TagEmbedded struct {
+			Promoted string `json:"promoted"`
+			Dup      string `json:"dup"`
+			Alias    string `json:"Bar"`
+		}
+		TagEmbedded2 struct {
+			Bar string
+		}
+		TagNamed struct {
+			Inner string `json:"inner"`
+		}
+		tagStruct struct {
+			ID       int    `json:"user_id"`
+			Name     string `json:"name,omitempty"`
+			Ignored  string `json:"-"`
+			Dash     string `json:"-,"`
+			NoTag    string
+			OptsOnly string `json:",omitempty"`
+			TagEmbedded
+			TagEmbedded2
+			TagNamed `json:"named"`
+			Dup      string
+		}
+	)
+
+	ts := tagStruct{
+		ID:           1,
+		Name:         "name",
+		Ignored:      "ignored",
+		Dash:         "dash",
+		NoTag:        "notag",
+		OptsOnly:     "optsonly",
+		TagEmbedded:  TagEmbedded{Promoted: "promoted", Dup: "embedded dup", Alias: "tagged bar"},
+		TagEmbedded2: TagEmbedded2{Bar: "untagged bar"},
+		TagNamed:     TagNamed{Inner: "inner"},
+		Dup:          "top dup",
+	}
+
+	g, err := NewGetterWithTag(&ts, "json")
+	if err != nil {
+		t.Fatalf("NewGetterWithTag() unexpected error [%v] occured.", err)
+	}
+
+	wantNames := []string{"user_id", "name", "-", "NoTag", "OptsOnly", "TagEmbedded", "TagEmbedded2", "named", "Dup"}
+	if d := cmp.Diff(g.Names(), wantNames); d != "" {
+		t.Errorf("Names() unexpected result. (-got +want)\n%s", d)
+	}
+
+	tests := []struct {
+		name    string
+		wantHas bool
+		want    interface{}
+	}{
+		{name: "user_id", wantHas: true, want: 1},
+		{name: "name", wantHas: true, want: "name"},
+		{name: "-", wantHas: true, want: "dash"},
+		{name: "NoTag", wantHas: true, want: "notag"},
+		{name: "OptsOnly", wantHas: true, want: "optsonly"},
+		{name: "promoted", wantHas: true, want: "promoted"},
+		{name: "dup", wantHas: true, want: "embedded dup"},
+		{name: "Dup", wantHas: true, want: "top dup"},
+		{name: "Bar", wantHas: true, want: "tagged bar"},
+		{name: "named", wantHas: true, want: TagNamed{Inner: "inner"}},
+		{name: "inner", wantHas: false, want: nil},
+		{name: "ID", wantHas: false, want: nil},
+		{name: "Ignored", wantHas: false, want: nil},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, has := g.Get(tt.name)
+			if has != tt.wantHas {
+				t.Errorf("Get() unexpected has. got: %v, want: %v", has, tt.wantHas)
+			}
+			if got != tt.want {
+				t.Errorf("Get() unexpected result. got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetterUnexported(t *testing.T) {
+	t.Parallel()
+
+	type (
+		inner struct {
+			secret int
+		}
+		outer struct {
+			private string
+			nested  *inner
+			inner
+		}
+	)
+
+	tests := []struct {
+		name string
+		arg  interface{}
+	}{
+		{
+			name: "struct ptr",
+			arg:  &outer{private: "p", nested: &inner{secret: 1}, inner: inner{secret: 2}},
+		},
+		{
+			name: "struct value",
+			arg:  outer{private: "p", nested: &inner{secret: 1}, inner: inner{secret: 2}},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			g, err := NewGetter(tt.arg)
+			if err != nil {
+				t.Fatalf("NewGetter() unexpected error [%v] occured.", err)
+			}
+			if got, has := g.Get("private"); !has || got != nil {
+				t.Errorf("Get() without Unexported option unexpected result. got: %v, has: %v", got, has)
+			}
+
+			g, err = NewGetterWithOption(tt.arg, &GetterOption{Unexported: true})
+			if err != nil {
+				t.Fatalf("NewGetterWithOption() unexpected error [%v] occured.", err)
+			}
+			if got, ok := g.String("private"); !ok || got != "p" {
+				t.Errorf("String() unexpected result. got: %v, ok: %v", got, ok)
+			}
+			if got, ok := g.Int("secret"); !ok || got != 2 {
+				t.Errorf("Int() for promoted unexported field unexpected result. got: %v, ok: %v", got, ok)
+			}
+
+			f, err := NewFinderWithGetter(g)
+			if err != nil {
+				t.Fatalf("NewFinderWithGetter() unexpected error [%v] occured.", err)
+			}
+			m, err := f.Into("nested").Find("secret").ToMap()
+			if err != nil {
+				t.Fatalf("ToMap() unexpected error [%v] occured.", err)
+			}
+			if m["nested.secret"] != 1 {
+				t.Errorf("ToMap() unexpected result. got: %v", m)
+			}
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	g, err := newTestGetter()
+	if err != nil {
+		t.Fatalf("NewGetter() unexpected error [%v] occured.", err)
+	}
+
+	got, err := g.Lookup("String")
+	if err != nil || got != "test name" {
+		t.Errorf("Lookup() unexpected result. got: %v, err: %v", got, err)
+	}
+
+	_, err = g.Lookup("NotExist")
+	var fe *FieldError
+	if !errors.Is(err, ErrFieldNotFound) || !errors.As(err, &fe) || fe.Name != "NotExist" {
+		t.Errorf("Lookup() unexpected error: %v", err)
+	}
+}
+
+func TestLookupAs(t *testing.T) {
+	t.Parallel()
+
+	g, err := newTestGetter()
+	if err != nil {
+		t.Fatalf("NewGetter() unexpected error [%v] occured.", err)
+	}
+
+	var str string
+	if err := g.LookupAs("String", &str); err != nil || str != "test name" {
+		t.Errorf("LookupAs() unexpected result. got: %v, err: %v", str, err)
+	}
+
+	// pointer fields are indirected unless target points to the pointer type
+	if err := g.LookupAs("Stringptr", &str); err != nil || str != getterTestString2 {
+		t.Errorf("LookupAs() unexpected result for indirected pointer. got: %v, err: %v", str, err)
+	}
+	var strptr *string
+	if err := g.LookupAs("Stringptr", &strptr); err != nil || strptr == nil || *strptr != getterTestString2 {
+		t.Errorf("LookupAs() unexpected result for pointer. got: %v, err: %v", strptr, err)
+	}
+
+	var intf interface{}
+	if err := g.LookupAs("Int64", &intf); err != nil || intf != int64(math.MinInt64) {
+		t.Errorf("LookupAs() unexpected result for interface. got: %v, err: %v", intf, err)
+	}
+
+	var i int
+	err = g.LookupAs("String", &i)
+	var fe *FieldError
+	if !errors.Is(err, ErrTypeMismatch) || !errors.As(err, &fe) {
+		t.Fatalf("LookupAs() unexpected error for type mismatch: %v", err)
+	}
+	if fe.Expected != reflect.TypeOf(i) || fe.Actual != reflect.TypeOf(str) {
+		t.Errorf("LookupAs() unexpected FieldError. got: %+v", fe)
+	}
+
+	if err := g.LookupAs("NotExist", &i); !errors.Is(err, ErrFieldNotFound) {
+		t.Errorf("LookupAs() unexpected error for not found: %v", err)
+	}
+	if err := g.LookupAs("privateString", &str); err == nil {
+		t.Errorf("LookupAs() error did not occur for unexported field")
+	}
+	if err := g.LookupAs("String", str); err == nil {
+		t.Errorf("LookupAs() error did not occur for non pointer target")
+	}
+}
+
+func TestFields(t *testing.T) {
+	t.Parallel()
+
+	type (
+		Company struct {
+			Name    string `json:"company_name"`
+			Address string
+		}
+		Person struct {
+			Name string
+			*Company
+			Age int `json:"age"`
+		}
+	)
+
+	g, err := NewGetter(&Person{})
+	if err != nil {
+		t.Fatalf("NewGetter() unexpected error [%v] occured.", err)
+	}
+
+	want := []FieldInfo{
+		{Name: "Name", Index: []int{0}, Depth: 0, Exported: true, Type: reflect.TypeOf("")},
+		{Name: "Company", Index: []int{1}, Depth: 0, Exported: true, Anonymous: true, Type: reflect.TypeOf(&Company{})},
+		{Name: "Address", Index: []int{1, 1}, Depth: 1, Exported: true, Type: reflect.TypeOf("")},
+		{Name: "Age", Index: []int{2}, Depth: 0, Exported: true, Tag: `json:"age"`, Type: reflect.TypeOf(0)},
+	}
+	if d := cmp.Diff(g.Fields(), want, cmp.Comparer(func(x, y reflect.Type) bool { return x == y })); d != "" {
+		t.Errorf("Fields() unexpected result. (-got +want)\n%s", d)
+	}
+
+	g, err = NewGetterWithOption(&Person{}, &GetterOption{Tag: "json", FlattenEmbedded: true})
+	if err != nil {
+		t.Fatalf("NewGetterWithOption() unexpected error [%v] occured.", err)
+	}
+
+	if d := cmp.Diff(g.Names(), []string{"Name", "company_name", "Address", "age"}); d != "" {
+		t.Errorf("Names() with FlattenEmbedded unexpected result. (-got +want)\n%s", d)
+	}
+	for _, name := range g.Names() {
+		if !g.Has(name) {
+			t.Errorf("Has() is false for flattened name %s", name)
+		}
+	}
+}
+
+func TestNewGetterWithMap(t *testing.T) {
+	t.Parallel()
+
+	str := "ptr"
+	m := map[string]interface{}{
+		"String":    "abc",
+		"Int":       1,
+		"Stringptr": &str,
+		"Nil":       nil,
+		"Slice": []interface{}{
+			map[string]interface{}{"Name": "a"},
+			map[string]interface{}{"Name": "b"},
+		},
+		"Struct": GetterTestStruct4{String: "s", String2: "s2"},
+	}
+
+	g, err := NewGetter(m)
+	if err != nil {
+		t.Fatalf("NewGetter() unexpected error [%v] occured.", err)
+	}
+
+	if g.NumField() != len(m) {
+		t.Errorf("NumField() unexpected result. got: %d, want: %d", g.NumField(), len(m))
+	}
+	if d := cmp.Diff(g.Names(), []string{"Int", "Nil", "Slice", "String", "Stringptr", "Struct"}); d != "" {
+		t.Errorf("Names() unexpected result. (-got +want)\n%s", d)
+	}
+
+	if !g.Has("Nil") || g.Has("NotExist") {
+		t.Errorf("Has() unexpected result")
+	}
+	if got, ok := g.String("String"); !ok || got != "abc" {
+		t.Errorf("String() unexpected result. got: %v, ok: %v", got, ok)
+	}
+	if got, ok := g.String("Stringptr"); !ok || got != "ptr" {
+		t.Errorf("String() for pointer unexpected result. got: %v, ok: %v", got, ok)
+	}
+	if got, ok := g.Int("Int"); !ok || got != 1 {
+		t.Errorf("Int() unexpected result. got: %v, ok: %v", got, ok)
+	}
+	if _, ok := g.Int("String"); ok {
+		t.Errorf("Int() for string value is ok")
+	}
+	if got, has := g.Get("Nil"); !has || got != nil {
+		t.Errorf("Get() for nil value unexpected result. got: %v, has: %v", got, has)
+	}
+	if !g.IsString("String") || !g.IsSlice("Slice") || !g.IsStruct("Struct") || g.IsString("Int") {
+		t.Errorf("Is*() unexpected result for dynamic types")
+	}
+	if typ, _ := g.GetType("Stringptr"); typ != reflect.TypeOf(&str) {
+		t.Errorf("GetType() unexpected result. got: %v", typ)
+	}
+
+	res, err := g.MapGet("Slice", func(i int, eg *Getter) (interface{}, error) {
+		return eg.AsString("Name")
+	})
+	if err != nil {
+		t.Fatalf("MapGet() unexpected error [%v] occured.", err)
+	}
+	if d := cmp.Diff(res, []interface{}{"a", "b"}); d != "" {
+		t.Errorf("MapGet() unexpected result. (-got +want)\n%s", d)
+	}
+
+	if _, err := NewGetter(map[int]string{1: "a"}); err == nil {
+		t.Errorf("NewGetter() error did not occur for non string key map")
+	}
+}
+
 func TestNumField(t *testing.T) {
 	t.Parallel()
 
@@ -2036,6 +2514,13 @@ func TestMapGet(t *testing.T) {
 					return fmt.Sprintf("%s:%s", str, str2), nil
 				}
 				tt.wantIntf = []interface{}{string("key991:value991"), string("key992:value992")}
+			case "Map":
+				tt.args.mapfn = func(i int, g *Getter) (interface{}, error) {
+					k, _ := g.Get("Key")
+					v, _ := g.Get("Value")
+					return fmt.Sprintf("%d:%v=%v", i, k, v), nil
+				}
+				tt.wantIntf = []interface{}{string("0:k1=v1"), string("1:k2=2")}
 			default:
 				tt.wantError = true
 			}
@@ -2057,6 +2542,184 @@ func TestMapGet(t *testing.T) {
 	}
 }
 
+type collectionTestItem struct {
+	Name  string
+	Group string
+	Score int
+}
+
+type collectionTestStruct struct {
+	Items  []*collectionTestItem
+	Array  [3]collectionTestItem
+	Scores map[string]int
+	Ints   []int
+}
+
+func newCollectionTestGetter(t *testing.T) *Getter {
+	items := []*collectionTestItem{
+		{Name: "a", Group: "x", Score: 3},
+		{Name: "b", Group: "y", Score: 1},
+		{Name: "c", Group: "x", Score: 2},
+	}
+
+	g, err := NewGetter(&collectionTestStruct{
+		Items:  items,
+		Array:  [3]collectionTestItem{*items[0], *items[1], *items[2]},
+		Scores: map[string]int{"b": 1, "a": 3, "c": 2},
+		Ints:   []int{1, 2},
+	})
+	if err != nil {
+		t.Fatalf("NewGetter() unexpected error [%v] occured.", err)
+	}
+
+	return g
+}
+
+func getterNames(egs []*Getter, name string) []string {
+	res := make([]string, len(egs))
+	for i, eg := range egs {
+		res[i], _ = eg.AsString(name)
+	}
+	return res
+}
+
+func TestFilterGet(t *testing.T) {
+	t.Parallel()
+
+	g := newCollectionTestGetter(t)
+	isX := func(i int, eg *Getter) (bool, error) {
+		group, _ := eg.String("Group")
+		return group == "x", nil
+	}
+
+	for _, name := range []string{"Items", "Array"} {
+		got, err := g.FilterGet(name, isX)
+		if err != nil {
+			t.Fatalf("FilterGet(%s) unexpected error [%v] occured.", name, err)
+		}
+		if d := cmp.Diff(getterNames(got, "Name"), []string{"a", "c"}); d != "" {
+			t.Errorf("FilterGet(%s) unexpected result. (-got +want)\n%s", name, d)
+		}
+	}
+
+	got, err := g.FilterGet("Scores", func(i int, eg *Getter) (bool, error) {
+		v, _ := eg.AsInt("Value")
+		return v >= 2, nil
+	})
+	if err != nil {
+		t.Fatalf("FilterGet() unexpected error [%v] occured.", err)
+	}
+	if d := cmp.Diff(getterNames(got, "Key"), []string{"a", "c"}); d != "" {
+		t.Errorf("FilterGet() for map unexpected result. (-got +want)\n%s", d)
+	}
+
+	if _, err := g.FilterGet("Items", func(i int, eg *Getter) (bool, error) { return false, fmt.Errorf("error") }); err == nil {
+		t.Errorf("FilterGet() error did not occur")
+	}
+	if _, err := g.FilterGet("Ints", isX); err == nil {
+		t.Errorf("FilterGet() error did not occur for non struct elements")
+	}
+	if _, err := g.FilterGet("NotExist", isX); err == nil {
+		t.Errorf("FilterGet() error did not occur for non existent field")
+	}
+}
+
+func TestReduceGet(t *testing.T) {
+	t.Parallel()
+
+	g := newCollectionTestGetter(t)
+	sum := func(acc interface{}, i int, eg *Getter) (interface{}, error) {
+		score, err := eg.AsInt("Score")
+		if err != nil {
+			score, err = eg.AsInt("Value")
+		}
+		return acc.(int) + score, err
+	}
+
+	for _, name := range []string{"Items", "Array", "Scores"} {
+		got, err := g.ReduceGet(name, 0, sum)
+		if err != nil {
+			t.Fatalf("ReduceGet(%s) unexpected error [%v] occured.", name, err)
+		}
+		if got != 6 {
+			t.Errorf("ReduceGet(%s) unexpected result. got: %v, want: 6", name, got)
+		}
+	}
+
+	if _, err := g.ReduceGet("Ints", 0, sum); err == nil {
+		t.Errorf("ReduceGet() error did not occur for non struct elements")
+	}
+}
+
+func TestGroupByGet(t *testing.T) {
+	t.Parallel()
+
+	g := newCollectionTestGetter(t)
+
+	got, err := g.GroupByGet("Items", func(i int, eg *Getter) (interface{}, error) {
+		group, _ := eg.String("Group")
+		return group, nil
+	})
+	if err != nil {
+		t.Fatalf("GroupByGet() unexpected error [%v] occured.", err)
+	}
+	if len(got) != 2 {
+		t.Errorf("GroupByGet() unexpected num of groups. got: %d", len(got))
+	}
+	if d := cmp.Diff(getterNames(got["x"], "Name"), []string{"a", "c"}); d != "" {
+		t.Errorf("GroupByGet() unexpected result of group x. (-got +want)\n%s", d)
+	}
+	if d := cmp.Diff(getterNames(got["y"], "Name"), []string{"b"}); d != "" {
+		t.Errorf("GroupByGet() unexpected result of group y. (-got +want)\n%s", d)
+	}
+
+	_, err = g.GroupByGet("Items", func(i int, eg *Getter) (interface{}, error) {
+		return []int{i}, nil
+	})
+	if err == nil {
+		t.Errorf("GroupByGet() error did not occur for non comparable key")
+	}
+}
+
+func TestSortByGet(t *testing.T) {
+	t.Parallel()
+
+	g := newCollectionTestGetter(t)
+	byScore := func(x, y *Getter) bool {
+		xs, _ := x.Int("Score")
+		ys, _ := y.Int("Score")
+		return xs < ys
+	}
+
+	for _, name := range []string{"Items", "Array"} {
+		got, err := g.SortByGet(name, byScore)
+		if err != nil {
+			t.Fatalf("SortByGet(%s) unexpected error [%v] occured.", name, err)
+		}
+		if d := cmp.Diff(getterNames(got, "Name"), []string{"b", "c", "a"}); d != "" {
+			t.Errorf("SortByGet(%s) unexpected result. (-got +want)\n%s", name, d)
+		}
+	}
+
+	// the original field is not modified
+	items, _ := g.Get("Items")
+	if items.([]*collectionTestItem)[0].Name != "a" {
+		t.Errorf("SortByGet() modified the original field")
+	}
+
+	got, err := g.SortByGet("Scores", func(x, y *Getter) bool {
+		xv, _ := x.Int("Value")
+		yv, _ := y.Int("Value")
+		return xv > yv
+	})
+	if err != nil {
+		t.Fatalf("SortByGet() unexpected error [%v] occured.", err)
+	}
+	if d := cmp.Diff(getterNames(got, "Key"), []string{"a", "c", "b"}); d != "" {
+		t.Errorf("SortByGet() for map unexpected result. (-got +want)\n%s", d)
+	}
+}
+
 // benchmark tests
 
 func BenchmarkNewGetter_Val(b *testing.B) {
@@ -2291,3 +2954,19 @@ func BenchmarkGetterMapGet(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkGetterGet_String_Concurrent(b *testing.B) {
+	g, err := NewGetterWithOption(newGetterTestStructPtr(), &GetterOption{Concurrent: true})
+	if err != nil {
+		b.Fatalf("NewGetterWithOption() occurs unexpected error: %v", err)
+		return
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			it, _ := g.Get("String")
+			_ = it
+		}
+	})
+}
@@ -3,7 +3,7 @@ module github.com/goldeneggg/structil
 go 1.15
 
 require (
-	github.com/fsnotify/fsnotify v1.4.9 // indirect
+	github.com/fsnotify/fsnotify v1.4.9
 	github.com/google/go-cmp v0.5.2
 	github.com/iancoleman/strcase v0.0.0-20191112232945-16388991a334
 	github.com/mitchellh/mapstructure v1.3.3
@@ -18,4 +18,5 @@ require (
 	golang.org/x/sys v0.0.0-20200808120158-1030fc2bf1d9 // indirect
 	golang.org/x/tools v0.0.0-20200809012840-6f4f008689da // indirect
 	gopkg.in/ini.v1 v1.57.0 // indirect
+	gopkg.in/yaml.v2 v2.3.0
 )
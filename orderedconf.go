@@ -0,0 +1,175 @@
+package structil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// isOrderedFormat reports whether configuration in format is parsed with the declaration order of maps.
+func isOrderedFormat(format string) bool {
+	switch strings.ToLower(format) {
+	case "yaml", "yml", "json":
+		return true
+	default:
+		return false
+	}
+}
+
+// parseOrderedConf parses configuration b in format that isOrderedFormat reports true.
+// Maps in the configuration are parsed into yaml.MapSlice to keep the declaration order.
+func parseOrderedConf(b []byte, format string) (confKeys, error) {
+	var top interface{}
+	if strings.ToLower(format) == "json" {
+		dec := json.NewDecoder(bytes.NewReader(b))
+		v, err := decodeOrderedJSON(dec)
+		if err != nil {
+			return confKeys{}, err
+		}
+		if _, err := dec.Token(); err != io.EOF {
+			return confKeys{}, fmt.Errorf("invalid data after top-level value")
+		}
+		top = v
+	} else {
+		var ms yaml.MapSlice
+		if err := yaml.Unmarshal(b, &ms); err != nil {
+			return confKeys{}, err
+		}
+		top = ms
+	}
+
+	items, isMap, err := mapItems(top)
+	if err != nil {
+		return confKeys{}, err
+	}
+	if !isMap {
+		return confKeys{}, fmt.Errorf("top-level value is not a map")
+	}
+
+	// top-level keys are case-insensitive as well as viper
+	var ck confKeys
+	found := false
+	for _, item := range items {
+		if !strings.EqualFold(item.Key.(string), "keys") {
+			continue
+		}
+		if found {
+			return confKeys{}, fmt.Errorf("Keys is duplicated")
+		}
+		found = true
+
+		if item.Value == nil {
+			continue
+		}
+		keys, ok := item.Value.([]interface{})
+		if !ok {
+			return confKeys{}, fmt.Errorf("Keys is not a list: %#v", item.Value)
+		}
+		ck.Keys = keys
+	}
+
+	return ck, nil
+}
+
+// decodeOrderedJSON decodes the next JSON value in dec.
+// Objects are decoded into yaml.MapSlice to keep the declaration order.
+func decodeOrderedJSON(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		ms := yaml.MapSlice{}
+		for dec.More() {
+			kt, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			v, err := decodeOrderedJSON(dec)
+			if err != nil {
+				return nil, err
+			}
+			ms = append(ms, yaml.MapItem{Key: kt, Value: v})
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return ms, nil
+	case json.Delim('['):
+		res := []interface{}{}
+		for dec.More() {
+			v, err := decodeOrderedJSON(dec)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, v)
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return res, nil
+	default:
+		return tok, nil
+	}
+}
+
+// mapItems returns items of map v that have string keys.
+// Items of yaml.MapSlice keep the declaration order, and items of other maps are sorted by keys.
+// isMap is false if v is not a map.
+func mapItems(v interface{}) (items yaml.MapSlice, isMap bool, err error) {
+	switch t := v.(type) {
+	case yaml.MapSlice:
+		for _, item := range t {
+			if _, ok := item.Key.(string); !ok {
+				return nil, true, fmt.Errorf("unsupported key: %#v", item.Key)
+			}
+		}
+		return t, true, nil
+	case map[string]interface{}:
+		for k, e := range t {
+			items = append(items, yaml.MapItem{Key: k, Value: e})
+		}
+	case map[interface{}]interface{}:
+		for k, e := range t {
+			if _, ok := k.(string); !ok {
+				return nil, true, fmt.Errorf("unsupported key: %#v", k)
+			}
+			items = append(items, yaml.MapItem{Key: k, Value: e})
+		}
+	default:
+		return nil, false, nil
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Key.(string) < items[j].Key.(string)
+	})
+
+	return items, true, nil
+}
+
+// plainValue converts yaml.MapSlice in v into map[string]interface{} recursively.
+func plainValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case yaml.MapSlice:
+		res := make(map[string]interface{}, len(t))
+		for _, item := range t {
+			res[fmt.Sprint(item.Key)] = plainValue(item.Value)
+		}
+		return res
+	case []interface{}:
+		res := make([]interface{}, len(t))
+		for i, e := range t {
+			res[i] = plainValue(e)
+		}
+		return res
+	default:
+		return v
+	}
+}
@@ -0,0 +1,137 @@
+package structil
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// MapGetParallel returns the interface slice of mapped values of the original struct field named name
+// as well as MapGet, but f is called concurrently by workers goroutines.
+// If workers is less than 1, runtime.GOMAXPROCS(0) is used.
+// Mapped values are in the order of elements.
+//
+// This fails fast. If f returns an error, the context passed to f is canceled, no more elements are processed
+// and the first error returned by f is returned. Errors of elements caused by the cancellation are ignored.
+// If ctx is canceled before all elements are processed, ctx.Err() is returned.
+func (g *Getter) MapGetParallel(ctx context.Context, name string, workers int, f func(context.Context, int, *Getter) (interface{}, error)) ([]interface{}, error) {
+	res, errs, err := g.mapGetParallel(ctx, name, workers, f, true)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// MapGetParallelAll returns the interface slice of mapped values of the original struct field named name
+// as well as MapGetParallel, but all elements are processed even if f returns errors.
+// If any errors occur, *MapGetError that holds the errors by index is returned with mapped values.
+// Mapped values of elements that errors occurred are nil.
+// If ctx is canceled, elements that are not processed yet have ctx.Err() as their errors.
+func (g *Getter) MapGetParallelAll(ctx context.Context, name string, workers int, f func(context.Context, int, *Getter) (interface{}, error)) ([]interface{}, error) {
+	res, errs, err := g.mapGetParallel(ctx, name, workers, f, false)
+	if err != nil {
+		return nil, err
+	}
+
+	me := &MapGetError{Name: name, Errors: map[int]error{}}
+	for i, err := range errs {
+		if err != nil {
+			me.Errors[i] = err
+		}
+	}
+
+	if len(me.Errors) > 0 {
+		return res, me
+	}
+
+	return res, nil
+}
+
+// mapGetParallel calls f for elements of the field named name by workers goroutines.
+// This returns mapped values and errors by index.
+// If failFast is true, f is not called any more after an error occurred.
+func (g *Getter) mapGetParallel(ctx context.Context, name string, workers int, f func(context.Context, int, *Getter) (interface{}, error), failFast bool) ([]interface{}, []error, error) {
+	egs, err := g.elemGetters(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(egs) {
+		workers = len(egs)
+	}
+
+	wctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	res := make([]interface{}, len(egs))
+	errs := make([]error, len(egs))
+	idxCh := make(chan int)
+
+	// the first error that canceled wctx in fail fast mode
+	var firstErr error
+	var once sync.Once
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range idxCh {
+				res[i], errs[i] = f(wctx, i, egs[i])
+				if errs[i] != nil {
+					res[i] = nil
+					if failFast {
+						err := errs[i]
+						once.Do(func() {
+							firstErr = err
+							cancel()
+						})
+					}
+				}
+			}
+		}()
+	}
+
+	dispatched := 0
+dispatch:
+	for i := range egs {
+		if wctx.Err() != nil {
+			break
+		}
+
+		select {
+		case <-wctx.Done():
+			break dispatch
+		case idxCh <- i:
+			dispatched++
+		}
+	}
+	close(idxCh)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, nil, firstErr
+	}
+
+	if dispatched < len(egs) {
+		if failFast {
+			return nil, nil, ctx.Err()
+		}
+
+		for i := dispatched; i < len(egs); i++ {
+			errs[i] = ctx.Err()
+		}
+	}
+
+	return res, errs, nil
+}
@@ -0,0 +1,245 @@
+package structil_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	. "github.com/goldeneggg/structil"
+)
+
+type parallelTestItem struct {
+	N int
+}
+
+type parallelTestStruct struct {
+	Nums []parallelTestItem
+	Str  string
+}
+
+func newParallelTestGetter(t *testing.T, n int) *Getter {
+	nums := make([]parallelTestItem, n)
+	for i := range nums {
+		nums[i] = parallelTestItem{N: i}
+	}
+
+	g, err := NewGetter(&parallelTestStruct{Nums: nums, Str: "str"})
+	if err != nil {
+		t.Fatalf("NewGetter() unexpected error [%v] occured.", err)
+	}
+
+	return g
+}
+
+func parallelTestN(eg *Getter) int {
+	n, _ := eg.Int("N")
+	return n
+}
+
+func TestMapGetParallel(t *testing.T) {
+	t.Parallel()
+
+	errOdd := errors.New("odd")
+
+	tests := []struct {
+		name    string
+		field   string
+		workers int
+		fn      func(context.Context, int, *Getter) (interface{}, error)
+		want    []interface{}
+		wantErr error
+	}{
+		{
+			name:    "preserve order",
+			field:   "Nums",
+			workers: 4,
+			fn: func(ctx context.Context, i int, eg *Getter) (interface{}, error) {
+				// later elements finish earlier
+				time.Sleep(time.Duration(10-i) * time.Millisecond)
+				return parallelTestN(eg) * 2, nil
+			},
+			want: []interface{}{0, 2, 4, 6, 8, 10, 12, 14, 16, 18},
+		},
+		{
+			name:    "default workers",
+			field:   "Nums",
+			workers: 0,
+			fn: func(ctx context.Context, i int, eg *Getter) (interface{}, error) {
+				return fmt.Sprint(parallelTestN(eg)), nil
+			},
+			want: []interface{}{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"},
+		},
+		{
+			name:    "fail fast",
+			field:   "Nums",
+			workers: 1,
+			fn: func(ctx context.Context, i int, eg *Getter) (interface{}, error) {
+				if i%2 == 1 {
+					return nil, errOdd
+				}
+				return i, nil
+			},
+			wantErr: errOdd,
+		},
+		{
+			name:    "not slice",
+			field:   "Str",
+			workers: 1,
+			fn: func(ctx context.Context, i int, eg *Getter) (interface{}, error) {
+				return nil, nil
+			},
+			wantErr: errors.New("field Str does not exist or is not slice, array or map type"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			g := newParallelTestGetter(t, 10)
+			got, err := g.MapGetParallel(context.Background(), tt.field, tt.workers, tt.fn)
+
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("MapGetParallel() unexpected error [%v] occured.", err)
+				}
+				if d := cmp.Diff(got, tt.want); d != "" {
+					t.Errorf("MapGetParallel() unexpected result. (-got +want)\n%s", d)
+				}
+			} else if err == nil || err.Error() != tt.wantErr.Error() {
+				t.Errorf("MapGetParallel() unexpected error. got: %v, want: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMapGetParallelFailFastCancel(t *testing.T) {
+	t.Parallel()
+
+	errFirst := errors.New("first")
+	var called int32
+
+	g := newParallelTestGetter(t, 100)
+	_, err := g.MapGetParallel(context.Background(), "Nums", 2, func(ctx context.Context, i int, eg *Getter) (interface{}, error) {
+		atomic.AddInt32(&called, 1)
+		if i == 0 {
+			return nil, errFirst
+		}
+
+		// wait for cancellation by the error of the first element
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+			return i, nil
+		}
+	})
+
+	if err != errFirst {
+		t.Errorf("MapGetParallel() unexpected error. got: %v, want: %v", err, errFirst)
+	}
+	if c := atomic.LoadInt32(&called); c >= 100 {
+		t.Errorf("MapGetParallel() did not stop processing. called: %d", c)
+	}
+}
+
+func TestMapGetParallelFailFastCause(t *testing.T) {
+	t.Parallel()
+
+	errBoom := errors.New("boom")
+
+	g := newParallelTestGetter(t, 8)
+	_, err := g.MapGetParallel(context.Background(), "Nums", 4, func(ctx context.Context, i int, eg *Getter) (interface{}, error) {
+		if i == 3 {
+			return nil, errBoom
+		}
+
+		// elements of smaller indexes are canceled by the error of index 3
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	if err != errBoom {
+		t.Errorf("MapGetParallel() unexpected error. got: %v, want: %v", err, errBoom)
+	}
+}
+
+func TestMapGetParallelContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	g := newParallelTestGetter(t, 100)
+	fn := func(ctx context.Context, i int, eg *Getter) (interface{}, error) {
+		if i == 1 {
+			cancel()
+		}
+		return i, nil
+	}
+
+	_, err := g.MapGetParallel(ctx, "Nums", 1, fn)
+	if err != context.Canceled {
+		t.Errorf("MapGetParallel() unexpected error. got: %v, want: %v", err, context.Canceled)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	got, err := g.MapGetParallelAll(ctx, "Nums", 1, fn)
+	var me *MapGetError
+	if !errors.As(err, &me) {
+		t.Fatalf("MapGetParallelAll() unexpected error [%v] occured.", err)
+	}
+	if len(got) != 100 || got[0] != 0 || got[1] != 1 {
+		t.Errorf("MapGetParallelAll() unexpected result. got: %v", got)
+	}
+	if me.Errors[99] != context.Canceled || !errors.Is(err, context.Canceled) {
+		t.Errorf("MapGetParallelAll() unexpected errors: %v", me.Errors)
+	}
+}
+
+func TestMapGetParallelAll(t *testing.T) {
+	t.Parallel()
+
+	errOdd := errors.New("odd")
+
+	g := newParallelTestGetter(t, 6)
+	got, err := g.MapGetParallelAll(context.Background(), "Nums", 3, func(ctx context.Context, i int, eg *Getter) (interface{}, error) {
+		if i%2 == 1 {
+			return i, fmt.Errorf("index %d: %w", i, errOdd)
+		}
+		return parallelTestN(eg), nil
+	})
+
+	if d := cmp.Diff(got, []interface{}{0, nil, 2, nil, 4, nil}); d != "" {
+		t.Errorf("MapGetParallelAll() unexpected result. (-got +want)\n%s", d)
+	}
+
+	var me *MapGetError
+	if !errors.As(err, &me) {
+		t.Fatalf("MapGetParallelAll() unexpected error [%v] occured.", err)
+	}
+	if d := cmp.Diff(me.Indexes(), []int{1, 3, 5}); d != "" {
+		t.Errorf("MapGetError.Indexes() unexpected result. (-got +want)\n%s", d)
+	}
+	if !errors.Is(err, errOdd) {
+		t.Errorf("errors.Is() is false. err: %v, target: %v", err, errOdd)
+	}
+
+	want := "field Nums: 3 errors occurred: [1] index 1: odd, [3] index 3: odd, [5] index 5: odd"
+	if err.Error() != want {
+		t.Errorf("MapGetParallelAll() unexpected error string. got: %s, want: %s", err.Error(), want)
+	}
+
+	_, err = g.MapGetParallelAll(context.Background(), "Nums", 3, func(ctx context.Context, i int, eg *Getter) (interface{}, error) {
+		return i, nil
+	})
+	if err != nil {
+		t.Errorf("MapGetParallelAll() unexpected error [%v] occured.", err)
+	}
+}
@@ -0,0 +1,487 @@
+package structil
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/goldeneggg/structil/util"
+)
+
+// wildcard matches all elements as an accessor (e.g. `Companies[*]`),
+// and all fields as a name.
+const wildcard = "*"
+
+// accessor is an index of slice or array, or a key of map.
+// e.g. "2" of `Companies[2]`, "env" of `Labels["env"]`.
+type accessor struct {
+	text   string
+	quoted bool
+}
+
+func (a accessor) String() string {
+	if a.quoted {
+		return "[" + strconv.Quote(a.text) + "]"
+	}
+	return "[" + a.text + "]"
+}
+
+// segment is a field name with accessors that is a part of path separated by the separator.
+// e.g. `Companies[2]` is parsed into name "Companies" and an accessor "2".
+type segment struct {
+	name      string
+	accessors []accessor
+}
+
+func (a accessor) isWildcard() bool {
+	return !a.quoted && a.text == wildcard
+}
+
+// parseSegment parses s into a segment.
+func parseSegment(s string) (*segment, error) {
+	pos := strings.IndexByte(s, '[')
+	if pos < 0 {
+		return &segment{name: s}, nil
+	}
+
+	seg := &segment{name: s[:pos]}
+	if seg.name == "" {
+		return nil, fmt.Errorf("name %s does not have a field name before %q", s, '[')
+	}
+
+	rest := s[pos:]
+	for rest != "" {
+		if rest[0] != '[' {
+			return nil, fmt.Errorf("name %s has unexpected character %q", s, rest[0])
+		}
+
+		var a accessor
+		if len(rest) > 1 && rest[1] == '"' {
+			q := quotedPrefix(rest[1:])
+			text, err := strconv.Unquote(q)
+			if err != nil {
+				return nil, fmt.Errorf("name %s has invalid quoted key %s: %v", s, q, err)
+			}
+			a.text = text
+			a.quoted = true
+			rest = rest[1+len(q):]
+			if rest == "" || rest[0] != ']' {
+				return nil, fmt.Errorf("name %s does not have closing %q", s, ']')
+			}
+			rest = rest[1:]
+		} else {
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("name %s does not have closing %q", s, ']')
+			}
+			a.text = rest[1:end]
+			if a.text == "" {
+				return nil, fmt.Errorf("name %s has empty %q", s, "[]")
+			}
+			rest = rest[end+1:]
+		}
+
+		seg.accessors = append(seg.accessors, a)
+	}
+
+	return seg, nil
+}
+
+// quotedPrefix returns the double-quoted string at the beginning of s.
+// If the closing quote does not exist, s is returned.
+func quotedPrefix(s string) string {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return s[:i+1]
+		}
+	}
+	return s
+}
+
+// hasAccessor reports whether name may have accessors.
+func hasAccessor(name string) bool {
+	return strings.IndexByte(name, '[') >= 0
+}
+
+// splitPath splits path by sep.
+// sep inside brackets (e.g. `Labels["a.b"]`) is not treated as the separator.
+func splitPath(path string, sep string) []string {
+	if !hasAccessor(path) {
+		return strings.Split(path, sep)
+	}
+
+	var res []string
+	depth := 0
+	inQuote := false
+	start := 0
+
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		switch {
+		case inQuote:
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inQuote = false
+			}
+		case c == '"' && depth > 0:
+			inQuote = true
+		case c == '[':
+			depth++
+		case c == ']':
+			if depth > 0 {
+				depth--
+			}
+		case depth == 0 && strings.HasPrefix(path[i:], sep):
+			res = append(res, path[start:i])
+			start = i + len(sep)
+			i += len(sep) - 1
+		}
+	}
+
+	return append(res, path[start:])
+}
+
+// escapeName escapes sep and backslash in name with backslash as well as Query.
+// Accessors are kept as they are because sep inside brackets is not treated as the separator.
+func escapeName(name string, sep string) string {
+	end := strings.IndexByte(name, '[')
+	if end < 0 {
+		end = len(name)
+	}
+	if !strings.Contains(name[:end], sep) && strings.IndexByte(name[:end], '\\') < 0 {
+		return name
+	}
+
+	var b strings.Builder
+	for i := 0; i < end; i++ {
+		switch {
+		case name[i] == '\\':
+			b.WriteString(`\\`)
+		case strings.HasPrefix(name[i:end], sep):
+			b.WriteByte('\\')
+			b.WriteString(sep)
+			i += len(sep) - 1
+		default:
+			b.WriteByte(name[i])
+		}
+	}
+	b.WriteString(name[end:])
+
+	return b.String()
+}
+
+// isWildcard reports whether name has wildcards.
+func isWildcard(name string) bool {
+	return name == wildcard || strings.Contains(name, "["+wildcard+"]")
+}
+
+// normalizeNames returns names that a wildcard name is merged into the previous name as an accessor.
+// e.g. ["Tags", "*"] is normalized into ["Tags[*]"].
+func normalizeNames(names []string) []string {
+	res := make([]string, 0, len(names))
+	for _, name := range names {
+		if name == wildcard && len(res) > 0 {
+			res[len(res)-1] += "[" + wildcard + "]"
+			continue
+		}
+		res = append(res, name)
+	}
+
+	return res
+}
+
+// lookup returns the indirected value of the field named name in g.
+// name can have accessors for slice, array and map. e.g. `Companies[2]`, `Labels["env"]`.
+// ErrFieldNotFound is returned if the field does not exist,
+// and the error wrapping ErrFieldNotFound is returned if the element indicated by accessors does not exist.
+func (g *Getter) lookup(name string) (reflect.Value, error) {
+	if !hasAccessor(name) {
+		if !g.Has(name) {
+			return reflect.Value{}, ErrFieldNotFound
+		}
+		v, _ := g.GetValue(name)
+		return v, nil
+	}
+
+	seg, err := parseSegment(name)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	if !g.Has(seg.name) {
+		return reflect.Value{}, ErrFieldNotFound
+	}
+
+	v, _ := g.GetValue(seg.name)
+	at := seg.name
+	for _, a := range seg.accessors {
+		v, err = access(v, a, at)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		at += a.String()
+	}
+
+	return v, nil
+}
+
+// lookupAll returns the indirected values looked up by name that may have wildcards.
+// Name wildcard "*" looks up all exported fields (or all map values sorted by keys) in g.
+// Elements of nil are returned as the invalid Value.
+// ErrFieldNotFound is returned if the field does not exist.
+func (g *Getter) lookupAll(name string) ([]reflect.Value, error) {
+	if !isWildcard(name) {
+		v, err := g.lookup(name)
+		if err != nil {
+			return nil, err
+		}
+		return []reflect.Value{v}, nil
+	}
+
+	if name == wildcard {
+		return elems(g.rv, name, g.opt.Tag)
+	}
+
+	seg, err := parseSegment(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !g.Has(seg.name) {
+		return nil, ErrFieldNotFound
+	}
+
+	v, _ := g.GetValue(seg.name)
+	vs := []reflect.Value{v}
+	at := seg.name
+	for _, a := range seg.accessors {
+		next := make([]reflect.Value, 0, len(vs))
+		for _, v := range vs {
+			if a.isWildcard() {
+				evs, err := elems(v, at, g.opt.Tag)
+				if err != nil {
+					return nil, err
+				}
+				next = append(next, evs...)
+				continue
+			}
+
+			if !v.IsValid() {
+				next = append(next, v)
+				continue
+			}
+
+			ev, err := access(v, a, at)
+			if err != nil {
+				return nil, err
+			}
+			next = append(next, ev)
+		}
+		vs = next
+		at += a.String()
+	}
+
+	return vs, nil
+}
+
+// elems returns all indirected elements of v.
+// v must be a slice, array, map or struct. Map elements are sorted by keys.
+// at is used for error messages, and tag is used to ignore struct fields tagged with "-".
+func elems(v reflect.Value, at string, tag string) ([]reflect.Value, error) {
+	v = indirect(v)
+	if !v.IsValid() {
+		return nil, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		res := make([]reflect.Value, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			res[i] = indirect(v.Index(i))
+		}
+		return res, nil
+	case reflect.Map:
+		keys := sortedMapKeys(v)
+		res := make([]reflect.Value, len(keys))
+		for i, k := range keys {
+			res[i] = indirect(v.MapIndex(k))
+		}
+		return res, nil
+	case reflect.Struct:
+		return fields(v, tag), nil
+	default:
+		return nil, fmt.Errorf("%s is not slice, array, map or struct: %v", at, v.Kind())
+	}
+}
+
+// fields returns all indirected exported fields of struct v.
+// Fields tagged with "-" for tag are ignored.
+func fields(v reflect.Value, tag string) []reflect.Value {
+	res := make([]reflect.Value, 0, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		sf := v.Type().Field(i)
+		if sf.PkgPath != "" {
+			continue
+		}
+		if _, _, ok := fieldName(sf, tag); !ok {
+			continue
+		}
+		res = append(res, indirect(v.Field(i)))
+	}
+
+	return res
+}
+
+// sortedMapKeys returns keys of map v sorted for deterministic iteration.
+func sortedMapKeys(v reflect.Value) []reflect.Value {
+	keys := v.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		ki, kj := indirect(keys[i]), indirect(keys[j])
+		if ki.Kind() == kj.Kind() {
+			switch ki.Kind() {
+			case reflect.String:
+				return ki.String() < kj.String()
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				return ki.Int() < kj.Int()
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+				return ki.Uint() < kj.Uint()
+			case reflect.Float32, reflect.Float64:
+				return ki.Float() < kj.Float()
+			}
+		}
+		return fmt.Sprint(util.ToI(keys[i])) < fmt.Sprint(util.ToI(keys[j]))
+	})
+
+	return keys
+}
+
+// access returns the indirected element of v indicated by a.
+// The error wrapping ErrFieldNotFound is returned if the element does not exist.
+// at is used for error messages.
+func access(v reflect.Value, a accessor, at string) (reflect.Value, error) {
+	v = indirect(v)
+	if !v.IsValid() {
+		return reflect.Value{}, fmt.Errorf("%s is nil", at)
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if a.quoted {
+			return reflect.Value{}, fmt.Errorf("%s is %v. index must be an integer: %q", at, v.Kind(), a.text)
+		}
+		idx, err := strconv.Atoi(a.text)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("%s is %v. index must be an integer: %s", at, v.Kind(), a.text)
+		}
+		if idx < 0 || idx >= v.Len() {
+			return reflect.Value{}, newNotFoundError("index %d is out of range of %s (len %d)", idx, at, v.Len())
+		}
+		return indirect(v.Index(idx)), nil
+	case reflect.Map:
+		kv, err := mapKey(a.text, v.Type().Key())
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("%s has invalid key %q: %v", at, a.text, err)
+		}
+		ev := v.MapIndex(kv)
+		if !ev.IsValid() {
+			return reflect.Value{}, newNotFoundError("key %q does not exist in %s", a.text, at)
+		}
+		return indirect(ev), nil
+	default:
+		return reflect.Value{}, fmt.Errorf("%s is not slice, array or map: %v", at, v.Kind())
+	}
+}
+
+// settableAccess returns the settable element of v indicated by a.
+// nil pointers on the way are allocated.
+// Elements of map are settable if they are pointers, maps or slices.
+// at is used for error messages.
+func settableAccess(v reflect.Value, a accessor, at string) (reflect.Value, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			if v.Kind() == reflect.Interface || !v.CanSet() {
+				return reflect.Value{}, fmt.Errorf("%s is nil and not settable", at)
+			}
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if _, err := access(v, a, at); err != nil {
+			return reflect.Value{}, err
+		}
+		// access returns the indirected element, so re-obtain the element itself
+		idx, _ := strconv.Atoi(a.text)
+		ev := v.Index(idx)
+		if !ev.CanSet() {
+			return reflect.Value{}, fmt.Errorf("element %s%s is not settable", at, a)
+		}
+		return ev, nil
+	case reflect.Map:
+		ev, err := access(v, a, at)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		// access returns the indirected element, so elements of pointers are settable
+		if !ev.CanSet() && ev.Kind() != reflect.Map && ev.Kind() != reflect.Slice {
+			return reflect.Value{}, fmt.Errorf("element %s%s of map is not settable. it must be a pointer", at, a)
+		}
+		return ev, nil
+	default:
+		return reflect.Value{}, fmt.Errorf("%s is not slice, array or map: %v", at, v.Kind())
+	}
+}
+
+// indirect returns the value that v points to or v contains as an interface.
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		v = v.Elem()
+	}
+	return v
+}
+
+// mapKey converts s into a map key value typed t.
+func mapKey(s string, t reflect.Type) (reflect.Value, error) {
+	kv := reflect.New(t).Elem()
+
+	switch t.Kind() {
+	case reflect.String:
+		kv.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, t.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		kv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 10, t.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		kv.SetUint(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		kv.SetBool(b)
+	case reflect.Interface:
+		sv := reflect.ValueOf(s)
+		if !sv.Type().AssignableTo(t) {
+			return reflect.Value{}, fmt.Errorf("key type %s is not supported", t)
+		}
+		kv.Set(sv)
+	default:
+		return reflect.Value{}, fmt.Errorf("key type %s is not supported", t)
+	}
+
+	return kv, nil
+}
@@ -0,0 +1,254 @@
+package structil
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// queryParser parses a path expression for Finder.Query.
+//
+// The syntax is as follows.
+//
+//	list     = path { "," path }
+//	path     = element { sep element }
+//	element  = name | "{" list "}"
+//	name     = { char | "\" any | accessor }
+//	accessor = "[" ( quoted | { char except "]" } ) "]"
+//
+// Braces are expanded into multiple paths. e.g. `A.{B,C.D}` is expanded into `A.B` and `A.C.D`.
+type queryParser struct {
+	expr string
+	sep  string
+	pos  int
+}
+
+// parseQuery parses expr into paths that are names split by sep.
+func parseQuery(expr string, sep string) ([][]string, error) {
+	p := &queryParser{expr: expr, sep: sep}
+
+	paths, err := p.parseList()
+	if err != nil {
+		return nil, err
+	}
+	if !p.eof() {
+		return nil, p.errorf("unexpected %q", p.expr[p.pos])
+	}
+
+	return paths, nil
+}
+
+func (p *queryParser) errorf(format string, args ...interface{}) *SyntaxError {
+	return &SyntaxError{Expr: p.expr, Column: p.pos + 1, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *queryParser) eof() bool {
+	return p.pos >= len(p.expr)
+}
+
+func (p *queryParser) peek(c byte) bool {
+	return !p.eof() && p.expr[p.pos] == c
+}
+
+func (p *queryParser) atSep() bool {
+	return strings.HasPrefix(p.expr[p.pos:], p.sep)
+}
+
+// parseList parses paths separated by commas.
+func (p *queryParser) parseList() ([][]string, error) {
+	var res [][]string
+	for {
+		paths, err := p.parsePath()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, paths...)
+
+		if !p.peek(',') {
+			return res, nil
+		}
+		p.pos++
+	}
+}
+
+// parsePath parses elements separated by the separator.
+func (p *queryParser) parsePath() ([][]string, error) {
+	var head [][]string
+
+	if p.peek('{') {
+		start := p.pos
+		p.pos++
+
+		list, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		if !p.peek('}') {
+			if p.eof() {
+				p.pos = start
+				return nil, p.errorf("%q is not closed", '{')
+			}
+			return nil, p.errorf("unexpected %q", p.expr[p.pos])
+		}
+		p.pos++
+		head = list
+	} else {
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+		head = [][]string{{name}}
+	}
+
+	if p.eof() || !p.atSep() {
+		return head, nil
+	}
+	p.pos += len(p.sep)
+
+	tails, err := p.parsePath()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([][]string, 0, len(head)*len(tails))
+	for _, h := range head {
+		for _, t := range tails {
+			path := make([]string, 0, len(h)+len(t))
+			path = append(path, h...)
+			res = append(res, append(path, t...))
+		}
+	}
+
+	return res, nil
+}
+
+// parseName parses a name with accessors. Escaped characters are unescaped.
+func (p *queryParser) parseName() (string, error) {
+	var sb strings.Builder
+	start := p.pos
+
+	for !p.eof() && !p.atSep() {
+		c := p.expr[p.pos]
+		switch c {
+		case ',', '}':
+			return p.endName(start, sb.String())
+		case '{', ']':
+			return "", p.errorf("unexpected %q", c)
+		case '\\':
+			if p.pos+1 >= len(p.expr) {
+				return "", p.errorf("escape character is at the end")
+			}
+			sb.WriteByte(p.expr[p.pos+1])
+			p.pos += 2
+		case '[':
+			if p.pos == start {
+				return "", p.errorf("name does not exist before %q", c)
+			}
+			a, err := p.parseAccessor()
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(a)
+		default:
+			sb.WriteByte(c)
+			p.pos++
+		}
+	}
+
+	return p.endName(start, sb.String())
+}
+
+func (p *queryParser) endName(start int, name string) (string, error) {
+	if name == "" {
+		p.pos = start
+		return "", p.errorf("name is empty")
+	}
+
+	return name, nil
+}
+
+// parseAccessor parses an accessor and returns the text of it as it is.
+func (p *queryParser) parseAccessor() (string, error) {
+	start := p.pos
+	p.pos++
+
+	if p.peek('"') {
+		q := quotedPrefix(p.expr[p.pos:])
+		if _, err := strconv.Unquote(q); err != nil {
+			return "", p.errorf("invalid quoted key %s", q)
+		}
+		p.pos += len(q)
+	} else {
+		end := strings.IndexByte(p.expr[p.pos:], ']')
+		if end == 0 {
+			return "", p.errorf("accessor is empty")
+		}
+		if end > 0 {
+			p.pos += end
+		} else {
+			p.pos = len(p.expr)
+		}
+	}
+
+	if !p.peek(']') {
+		p.pos = start
+		return "", p.errorf("%q is not closed", '[')
+	}
+	p.pos++
+
+	return p.expr[start:p.pos], nil
+}
+
+// Query returns a Finder that fields are looked up by the path expression expr.
+// expr is names joined by the separator. The last name is looked up as well as Find, and the others as well as Into.
+// Braces group multiple names and paths, and commas separate paths.
+// e.g. `Company.Group.{Name,Boss}` is equivalent to Into("Company", "Group").Find("Name", "Boss").
+// Names can have accessors and wildcards as well as Find. e.g. `Companies[*].Name`, `Tags.*`.
+// Backslash escapes the separator, braces, brackets, commas and backslash in names. e.g. `Labels\.v1`.
+//
+// Unlike Find, names are added to names already looked up.
+// If expr is invalid, *SyntaxError is held in this Finder.
+func (f *Finder) Query(expr string) *Finder {
+	return f.query(expr, f.sep, nil)
+}
+
+// query looks up fields by expr as well as Query.
+// If spec is not nil, the fields are optional as well as FindOptional and FindWithDefault.
+func (f *Finder) query(expr string, sep string, spec *findSpec) *Finder {
+	if f.blocked() {
+		return f
+	}
+
+	paths, err := parseQuery(expr, sep)
+	if err != nil {
+		return f.addError(expr, err)
+	}
+
+	for _, path := range paths {
+		path = normalizeNames(path)
+		f.Into(path[:len(path)-1]...)
+		if f.blocked() {
+			return f
+		}
+
+		name := path[len(path)-1]
+		if !containsName(f.fMap[f.ck], name) {
+			f.fMap[f.ck] = append(f.fMap[f.ck], name)
+		}
+		if spec != nil {
+			f.sMap[f.key(f.ck, name)] = spec
+		}
+	}
+
+	return f
+}
+
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
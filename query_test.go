@@ -0,0 +1,166 @@
+package structil_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	. "github.com/goldeneggg/structil"
+)
+
+func TestQuery(t *testing.T) {
+	t.Parallel()
+
+	type escapeTestStruct struct {
+		Labels map[string]string
+		Nested map[string]map[string]int
+	}
+
+	tests := []struct {
+		name    string
+		newF    func() (*Finder, error)
+		exprs   []string
+		wantMap map[string]interface{}
+	}{
+		{
+			name:  "with brace group",
+			exprs: []string{"FinderTestStruct2Ptr.{String,FinderTestStruct3.{String,Int}}"},
+			wantMap: map[string]interface{}{
+				"FinderTestStruct2Ptr.String":                   "struct2 string ptr",
+				"FinderTestStruct2Ptr.FinderTestStruct3.String": "struct3 string ptr",
+				"FinderTestStruct2Ptr.FinderTestStruct3.Int":    -456,
+			},
+		},
+		{
+			name:  "with top level names separated by commas",
+			exprs: []string{"Int64,String", "FinderTestStruct2.String"},
+			wantMap: map[string]interface{}{
+				"Int64":                    int64(-1),
+				"String":                   "test name",
+				"FinderTestStruct2.String": "struct2 string",
+			},
+		},
+		{
+			name:  "with group in the middle of path",
+			exprs: []string{"{FinderTestStruct2,FinderTestStruct2Ptr}.FinderTestStruct3.Int"},
+			wantMap: map[string]interface{}{
+				"FinderTestStruct2.FinderTestStruct3.Int":    -123,
+				"FinderTestStruct2Ptr.FinderTestStruct3.Int": -456,
+			},
+		},
+		{
+			name:  "with accessors and wildcards",
+			exprs: []string{`{Stringslice[1],Map["k.1"],FinderTestStruct4Slice[*].String,Stringslice.*}`},
+			newF: func() (*Finder, error) {
+				s := newFinderTestStructPtr()
+				s.Map = map[string]interface{}{"k.1": "v"}
+				return NewFinder(s)
+			},
+			wantMap: map[string]interface{}{
+				"Stringslice[1]":                   "strslice2",
+				`Map["k.1"]`:                       "v",
+				"FinderTestStruct4Slice[*].String": []interface{}{"key100", "key200"},
+				"Stringslice[*]":                   []interface{}{"strslice1", "strslice2"},
+			},
+		},
+		{
+			name:  "with escaped separator",
+			exprs: []string{`Labels.a\.b`, `Nested.x\,y.{c}`},
+			newF: func() (*Finder, error) {
+				return NewFinder(&escapeTestStruct{
+					Labels: map[string]string{"a.b": "ab"},
+					Nested: map[string]map[string]int{"x,y": {"c": 1}},
+				})
+			},
+			wantMap: map[string]interface{}{
+				"Labels.a.b":   "ab",
+				"Nested.x,y.c": 1,
+			},
+		},
+		{
+			name:  "with custom separator",
+			exprs: []string{"FinderTestStruct2Ptr:{String,FinderTestStruct3:Int}"},
+			newF: func() (*Finder, error) {
+				return NewFinderWithSep(newFinderTestStructPtr(), ":")
+			},
+			wantMap: map[string]interface{}{
+				"FinderTestStruct2Ptr:String":                "struct2 string ptr",
+				"FinderTestStruct2Ptr:FinderTestStruct3:Int": -456,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			newF := tt.newF
+			if newF == nil {
+				newF = func() (*Finder, error) { return NewFinder(newFinderTestStructPtr()) }
+			}
+			f, err := newF()
+			if err != nil {
+				t.Fatalf("NewFinder() unexpected error [%v] occured.", err)
+			}
+
+			for _, expr := range tt.exprs {
+				f = f.Query(expr)
+			}
+
+			got, err := f.ToMap()
+			if err != nil {
+				t.Fatalf("ToMap() unexpected error [%v] occured.", err)
+			}
+			if d := cmp.Diff(got, tt.wantMap); d != "" {
+				t.Errorf("Query() unexpected result. (-got +want)\n%s", d)
+			}
+		})
+	}
+}
+
+func TestQuerySyntaxError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		expr       string
+		wantColumn int
+		wantMsg    string
+	}{
+		{expr: "", wantColumn: 1, wantMsg: "name is empty"},
+		{expr: "A..B", wantColumn: 3, wantMsg: "name is empty"},
+		{expr: "A.{B,C", wantColumn: 3, wantMsg: `'{' is not closed`},
+		{expr: "A.{B,}", wantColumn: 6, wantMsg: "name is empty"},
+		{expr: "A.B}", wantColumn: 4, wantMsg: `unexpected '}'`},
+		{expr: "A{B}", wantColumn: 2, wantMsg: `unexpected '{'`},
+		{expr: "A]", wantColumn: 2, wantMsg: `unexpected ']'`},
+		{expr: "A.[0]", wantColumn: 3, wantMsg: `name does not exist before '['`},
+		{expr: "A[0", wantColumn: 2, wantMsg: `'[' is not closed`},
+		{expr: "A[]", wantColumn: 3, wantMsg: "accessor is empty"},
+		{expr: `A["k]`, wantColumn: 3, wantMsg: `invalid quoted key "k]`},
+		{expr: `A\`, wantColumn: 2, wantMsg: "escape character is at the end"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.expr, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := NewFinder(newFinderTestStructPtr())
+			if err != nil {
+				t.Fatalf("NewFinder() unexpected error [%v] occured.", err)
+			}
+
+			_, err = f.Query(tt.expr).ToMap()
+
+			var se *SyntaxError
+			if !errors.As(err, &se) {
+				t.Fatalf("Query() unexpected error: %v", err)
+			}
+			if se.Expr != tt.expr || se.Column != tt.wantColumn || se.Msg != tt.wantMsg {
+				t.Errorf("Query() unexpected SyntaxError. got: %+v, want column: %d, msg: %s", se, tt.wantColumn, tt.wantMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package structil
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+// Setter is the struct that wraps the basic Setter method.
+type Setter struct {
+	rv   reflect.Value // Value of indirected input struct pointer
+	numf int           // Field nums
+	opt  *SetterOption // Options
+}
+
+// SetterOption is the option for Setter.
+type SetterOption struct {
+	// Unexported enables to write unexported fields using unsafe.
+	Unexported bool
+}
+
+// NewSetter returns a concrete Setter that writes into i.
+// i must be a non-nil struct pointer.
+func NewSetter(i interface{}) (*Setter, error) {
+	return NewSetterWithOption(i, nil)
+}
+
+// NewSetterWithOption returns a concrete Setter that writes into i with opt.
+// i must be a non-nil struct pointer.
+func NewSetterWithOption(i interface{}, opt *SetterOption) (*Setter, error) {
+	rv := reflect.ValueOf(i)
+	kind := rv.Kind()
+
+	if kind != reflect.Ptr {
+		return nil, fmt.Errorf("%+v is not supported kind: %v. value: %+v", i, kind, rv)
+	}
+
+	rv = reflect.Indirect(rv)
+	if !rv.IsValid() {
+		return nil, fmt.Errorf("%+v is invalid argument. value: %+v", i, rv)
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%+v is not supported kind: %v. value: %+v", i, rv.Kind(), rv)
+	}
+
+	if opt == nil {
+		opt = &SetterOption{}
+	}
+
+	return &Setter{
+		rv:   rv,
+		numf: rv.NumField(),
+		opt:  opt,
+	}, nil
+}
+
+// NumField returns num of struct field.
+func (s *Setter) NumField() int {
+	return s.numf
+}
+
+// Has tests whether the original struct has a field named name arg.
+func (s *Setter) Has(name string) bool {
+	_, ok := typeInfoOf(s.rv.Type(), "").fields[name]
+	return ok
+}
+
+// Set sets v into the original struct field named name.
+// If the field is a pointer and v is assignable to the pointed type, a new pointer to v is set.
+// An error is returned if the field does not exist, can not be set (e.g. unexported without Unexported option)
+// or v is not assignable to the field type.
+func (s *Setter) Set(name string, v interface{}) error {
+	fi, ok := typeInfoOf(s.rv.Type(), "").fields[name]
+	if !ok {
+		return newFieldNotFoundError(name)
+	}
+
+	// nil embedded struct pointers on the way to a promoted field are allocated
+	frv := fieldByIndex(s.rv, fi.index, true)
+	if !frv.IsValid() {
+		return fmt.Errorf("field %s is not reachable", name)
+	}
+	if s.opt.Unexported {
+		frv = unlock(frv)
+	}
+
+	return assign(frv, name, v)
+}
+
+// fieldByIndex returns the nested field of rv corresponding to index.
+// Unlike reflect.Value.FieldByIndex, this does not panic on nil embedded struct pointers.
+// If alloc is true and the pointers are settable, they are allocated.
+// Otherwise the invalid Value is returned.
+func fieldByIndex(rv reflect.Value, index []int, alloc bool) reflect.Value {
+	for i, x := range index {
+		if i > 0 && rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				if !alloc || !rv.CanSet() {
+					return reflect.Value{}
+				}
+				rv.Set(reflect.New(rv.Type().Elem()))
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+
+	return rv
+}
+
+// unlock returns the Value that refers to the same memory as addressable v without read-only restriction.
+// This enables to read and write unexported fields.
+// v is returned as it is if v is not addressable.
+func unlock(v reflect.Value) reflect.Value {
+	if !v.IsValid() || !v.CanAddr() || v.CanSet() {
+		return v
+	}
+
+	return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+}
+
+// assign sets v into frv that is a field named name.
+func assign(frv reflect.Value, name string, v interface{}) error {
+	if !frv.CanSet() {
+		return fmt.Errorf("field %s is not settable", name)
+	}
+
+	ft := frv.Type()
+
+	if v == nil {
+		switch ft.Kind() {
+		case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface, reflect.Func, reflect.Chan:
+			frv.Set(reflect.Zero(ft))
+			return nil
+		}
+		return newTypeMismatchError(name, ft, nil)
+	}
+
+	vrv := reflect.ValueOf(v)
+	vt := vrv.Type()
+
+	if vt.AssignableTo(ft) {
+		frv.Set(vrv)
+		return nil
+	}
+
+	if ft.Kind() == reflect.Ptr && vt.AssignableTo(ft.Elem()) {
+		prv := reflect.New(ft.Elem())
+		prv.Elem().Set(vrv)
+		frv.Set(prv)
+		return nil
+	}
+
+	return newTypeMismatchError(name, ft, vt)
+}
+
+// SetBool sets the bool v into the original struct field named name.
+func (s *Setter) SetBool(name string, v bool) error {
+	return s.Set(name, v)
+}
+
+// SetByte sets the byte v into the original struct field named name.
+func (s *Setter) SetByte(name string, v byte) error {
+	return s.Set(name, v)
+}
+
+// SetBytes sets the []byte v into the original struct field named name.
+func (s *Setter) SetBytes(name string, v []byte) error {
+	return s.Set(name, v)
+}
+
+// SetString sets the string v into the original struct field named name.
+func (s *Setter) SetString(name string, v string) error {
+	return s.Set(name, v)
+}
+
+// SetInt sets the int v into the original struct field named name.
+func (s *Setter) SetInt(name string, v int) error {
+	return s.Set(name, v)
+}
+
+// SetInt8 sets the int8 v into the original struct field named name.
+func (s *Setter) SetInt8(name string, v int8) error {
+	return s.Set(name, v)
+}
+
+// SetInt16 sets the int16 v into the original struct field named name.
+func (s *Setter) SetInt16(name string, v int16) error {
+	return s.Set(name, v)
+}
+
+// SetInt32 sets the int32 v into the original struct field named name.
+func (s *Setter) SetInt32(name string, v int32) error {
+	return s.Set(name, v)
+}
+
+// SetInt64 sets the int64 v into the original struct field named name.
+func (s *Setter) SetInt64(name string, v int64) error {
+	return s.Set(name, v)
+}
+
+// SetUint sets the uint v into the original struct field named name.
+func (s *Setter) SetUint(name string, v uint) error {
+	return s.Set(name, v)
+}
+
+// SetUint8 sets the uint8 v into the original struct field named name.
+func (s *Setter) SetUint8(name string, v uint8) error {
+	return s.Set(name, v)
+}
+
+// SetUint16 sets the uint16 v into the original struct field named name.
+func (s *Setter) SetUint16(name string, v uint16) error {
+	return s.Set(name, v)
+}
+
+// SetUint32 sets the uint32 v into the original struct field named name.
+func (s *Setter) SetUint32(name string, v uint32) error {
+	return s.Set(name, v)
+}
+
+// SetUint64 sets the uint64 v into the original struct field named name.
+func (s *Setter) SetUint64(name string, v uint64) error {
+	return s.Set(name, v)
+}
+
+// SetUintptr sets the uintptr v into the original struct field named name.
+func (s *Setter) SetUintptr(name string, v uintptr) error {
+	return s.Set(name, v)
+}
+
+// SetFloat32 sets the float32 v into the original struct field named name.
+func (s *Setter) SetFloat32(name string, v float32) error {
+	return s.Set(name, v)
+}
+
+// SetFloat64 sets the float64 v into the original struct field named name.
+func (s *Setter) SetFloat64(name string, v float64) error {
+	return s.Set(name, v)
+}
+
+// SetComplex64 sets the complex64 v into the original struct field named name.
+func (s *Setter) SetComplex64(name string, v complex64) error {
+	return s.Set(name, v)
+}
+
+// SetComplex128 sets the complex128 v into the original struct field named name.
+func (s *Setter) SetComplex128(name string, v complex128) error {
+	return s.Set(name, v)
+}
+
+// SetUnsafePointer sets the unsafe.Pointer v into the original struct field named name.
+func (s *Setter) SetUnsafePointer(name string, v unsafe.Pointer) error {
+	return s.Set(name, v)
+}
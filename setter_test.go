@@ -0,0 +1,290 @@
+package structil_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	. "github.com/goldeneggg/structil"
+)
+
+type (
+	SetterTestStruct struct {
+		String        string
+		Stringptr     *string
+		Int           int
+		Int64         int64
+		Uint8         uint8
+		Float64       float64
+		Bool          bool
+		Bytes         []byte
+		Map           map[string]interface{}
+		Intf          interface{}
+		privateString string
+		*SetterTestStruct2
+	}
+
+	SetterTestStruct2 struct {
+		Embedded string
+	}
+)
+
+func TestNewSetter(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		i interface{}
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "valid struct ptr",
+			args:    args{i: &SetterTestStruct{}},
+			wantErr: false,
+		},
+		{
+			name:    "invalid struct (not ptr)",
+			args:    args{i: SetterTestStruct{}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid struct ptr nil",
+			args:    args{i: (*SetterTestStruct)(nil)},
+			wantErr: true,
+		},
+		{
+			name:    "invalid (nil)",
+			args:    args{i: nil},
+			wantErr: true,
+		},
+		{
+			name:    "invalid (string ptr)",
+			args:    args{i: new(string)},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSetter(tt.args.i)
+
+			if err == nil {
+				if tt.wantErr {
+					t.Errorf("NewSetter() error did not occur. got: %v", got)
+					return
+				}
+			} else if !tt.wantErr {
+				t.Errorf("NewSetter() unexpected error [%v] occured. wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSetterHas(t *testing.T) {
+	t.Parallel()
+
+	s, err := NewSetter(&SetterTestStruct{})
+	if err != nil {
+		t.Fatalf("NewSetter() occurs unexpected error: %v", err)
+	}
+
+	for name, want := range map[string]bool{
+		"String":            true,
+		"privateString":     true,
+		"SetterTestStruct2": true,
+		"Embedded":          true,
+		"NotExist":          false,
+	} {
+		if got := s.Has(name); got != want {
+			t.Errorf("Has(%s) = %v, want: %v", name, got, want)
+		}
+	}
+}
+
+func TestSet(t *testing.T) {
+	t.Parallel()
+
+	str := "string ptr"
+
+	type args struct {
+		name  string
+		value interface{}
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    SetterTestStruct
+		wantErr bool
+	}{
+		{
+			name: "string",
+			args: args{name: "String", value: "abc"},
+			want: SetterTestStruct{String: "abc"},
+		},
+		{
+			name: "string ptr with string ptr",
+			args: args{name: "Stringptr", value: &str},
+			want: SetterTestStruct{Stringptr: &str},
+		},
+		{
+			name: "string ptr with string",
+			args: args{name: "Stringptr", value: str},
+			want: SetterTestStruct{Stringptr: &str},
+		},
+		{
+			name: "int",
+			args: args{name: "Int", value: 10},
+			want: SetterTestStruct{Int: 10},
+		},
+		{
+			name: "bytes",
+			args: args{name: "Bytes", value: []byte{0x01}},
+			want: SetterTestStruct{Bytes: []byte{0x01}},
+		},
+		{
+			name: "map with nil",
+			args: args{name: "Map", value: nil},
+			want: SetterTestStruct{},
+		},
+		{
+			name: "interface",
+			args: args{name: "Intf", value: 1.5},
+			want: SetterTestStruct{Intf: 1.5},
+		},
+		{
+			name: "promoted field through nil embedded ptr",
+			args: args{name: "Embedded", value: "embedded"},
+			want: SetterTestStruct{SetterTestStruct2: &SetterTestStruct2{Embedded: "embedded"}},
+		},
+		{
+			name:    "type mismatch",
+			args:    args{name: "Int64", value: 10},
+			wantErr: true,
+		},
+		{
+			name:    "nil into int",
+			args:    args{name: "Int", value: nil},
+			wantErr: true,
+		},
+		{
+			name:    "unexported",
+			args:    args{name: "privateString", value: "abc"},
+			wantErr: true,
+		},
+		{
+			name:    "not exist",
+			args:    args{name: "NotExist", value: "abc"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := &SetterTestStruct{}
+			s, err := NewSetter(got)
+			if err != nil {
+				t.Fatalf("NewSetter() occurs unexpected error: %v", err)
+			}
+
+			err = s.Set(tt.args.name, tt.args.value)
+			if err == nil {
+				if tt.wantErr {
+					t.Errorf("Set() error did not occur. got: %+v", got)
+					return
+				}
+
+				if d := cmp.Diff(*got, tt.want, cmp.AllowUnexported(SetterTestStruct{})); d != "" {
+					t.Errorf("unexpected mismatch: args: %+v, (-got +want)\n%s", tt.args, d)
+				}
+			} else if !tt.wantErr {
+				t.Errorf("Set() unexpected error [%v] occured. wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSetWithUnexported(t *testing.T) {
+	t.Parallel()
+
+	ts := &SetterTestStruct{}
+	s, err := NewSetterWithOption(ts, &SetterOption{Unexported: true})
+	if err != nil {
+		t.Fatalf("NewSetterWithOption() unexpected error [%v] occured.", err)
+	}
+
+	if err := s.Set("privateString", "abc"); err != nil {
+		t.Fatalf("Set() unexpected error [%v] occured.", err)
+	}
+	if ts.privateString != "abc" {
+		t.Errorf("Set() did not set unexported field. got: %s", ts.privateString)
+	}
+
+	if err := s.Set("privateString", 1); err == nil {
+		t.Errorf("Set() error did not occur for type mismatch value.")
+	}
+}
+
+func TestSetTyped(t *testing.T) {
+	t.Parallel()
+
+	got := &SetterTestStruct{}
+	s, err := NewSetter(got)
+	if err != nil {
+		t.Fatalf("NewSetter() occurs unexpected error: %v", err)
+	}
+
+	errs := []error{
+		s.SetString("String", "abc"),
+		s.SetInt("Int", -1),
+		s.SetInt64("Int64", -2),
+		s.SetUint8("Uint8", 3),
+		s.SetFloat64("Float64", 4.5),
+		s.SetBool("Bool", true),
+		s.SetBytes("Bytes", []byte("xyz")),
+	}
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("%d: unexpected error [%v] occured", i, err)
+		}
+	}
+
+	want := SetterTestStruct{
+		String:  "abc",
+		Int:     -1,
+		Int64:   -2,
+		Uint8:   3,
+		Float64: 4.5,
+		Bool:    true,
+		Bytes:   []byte("xyz"),
+	}
+	if d := cmp.Diff(*got, want, cmp.AllowUnexported(SetterTestStruct{})); d != "" {
+		t.Errorf("unexpected mismatch: (-got +want)\n%s", d)
+	}
+
+	if err := s.SetInt("Int64", 1); err == nil {
+		t.Errorf("SetInt() into int64 field error did not occur")
+	}
+}
+
+// benchmark tests
+
+func BenchmarkSetterSetString(b *testing.B) {
+	s, err := NewSetter(&SetterTestStruct{})
+	if err != nil {
+		b.Fatalf("NewSetter() occurs unexpected error: %v", err)
+		return
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err = s.SetString("String", "abc"); err != nil {
+			b.Fatalf("abort benchmark because error %v occurd.", err)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package structil
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/goldeneggg/structil/util"
+)
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// ToMapOption is the option for Getter.ToMapWithOption.
+type ToMapOption struct {
+	// Tag is the struct tag key (e.g. "json") used to name map keys.
+	// If this is empty, the Tag of GetterOption is used.
+	Tag string
+
+	// OmitZero omits struct fields that have zero values.
+	OmitZero bool
+
+	// MaxDepth limits the depth of converted maps and slices. The map returned is depth 1.
+	// Values deeper than MaxDepth are kept as original values. 0 means unlimited.
+	MaxDepth int
+}
+
+// toMapper converts values into maps and slices with ToMapOption.
+type toMapper struct {
+	tag        string
+	omitZero   bool
+	maxDepth   int
+	unexported bool
+	flatten    bool
+	visiting   map[visitKey]bool // values on the way to the current value to detect cycles
+}
+
+// ToMap returns the map that has names of fields as keys and interfaces of the fields as values.
+// Values are not converted as well as Get, so nested structs are kept as they are.
+// Unexported fields are not included unless Unexported option is true.
+func (g *Getter) ToMap() (map[string]interface{}, error) {
+	return g.ToMapWithOption(&ToMapOption{MaxDepth: 1})
+}
+
+// ToMapRecursive returns the map that the original struct is converted into recursively.
+// Nested structs and maps that have string keys are converted into map[string]interface{},
+// and slices and arrays are converted into []interface{}. []byte is kept as it is.
+// Values of types that implement json.Marshaler or encoding.TextMarshaler (e.g. time.Time) are kept as they are.
+//
+// This is the inverse of DynamicStruct.DecodeMap.
+// An error is returned if the original struct has a cyclic reference.
+func (g *Getter) ToMapRecursive() (map[string]interface{}, error) {
+	return g.ToMapWithOption(nil)
+}
+
+// ToMapWithOption returns the map that the original struct is converted into as well as ToMapRecursive with opt.
+func (g *Getter) ToMapWithOption(opt *ToMapOption) (map[string]interface{}, error) {
+	if opt == nil {
+		opt = &ToMapOption{}
+	}
+
+	tm := &toMapper{
+		tag:        opt.Tag,
+		omitZero:   opt.OmitZero,
+		maxDepth:   opt.MaxDepth,
+		unexported: g.opt.Unexported,
+		flatten:    g.opt.FlattenEmbedded,
+		visiting:   map[visitKey]bool{},
+	}
+	if tm.tag == "" {
+		tm.tag = g.opt.Tag
+	}
+
+	if g.ti == nil {
+		return tm.fromMap("", g.rv, 1)
+	}
+
+	if g.rv.CanAddr() {
+		tm.visiting[visitKey{typ: g.rv.Type(), addr: g.rv.UnsafeAddr()}] = true
+	}
+
+	return tm.fromStruct("", g.rv, 1)
+}
+
+// fromStruct converts struct rv at depth into a map.
+func (tm *toMapper) fromStruct(path string, rv reflect.Value, depth int) (map[string]interface{}, error) {
+	ti := typeInfoOf(rv.Type(), tm.tag)
+	names := ti.names
+	if tm.flatten {
+		names = ti.flatNames
+	}
+
+	res := make(map[string]interface{}, len(names))
+	for _, name := range names {
+		fi, ok := ti.fields[name]
+		if !ok {
+			// ambiguous names
+			continue
+		}
+
+		frv := fieldByIndex(rv, fi.index, false)
+		if tm.unexported {
+			frv = unlock(frv)
+		}
+		if !frv.IsValid() || !frv.CanInterface() {
+			continue
+		}
+		if tm.omitZero && frv.IsZero() {
+			continue
+		}
+
+		v, err := tm.convert(joinPath(path, name), frv, depth)
+		if err != nil {
+			return nil, err
+		}
+		res[name] = v
+	}
+
+	return res, nil
+}
+
+// fromMap converts map rv that has string keys at depth into a map.
+func (tm *toMapper) fromMap(path string, rv reflect.Value, depth int) (map[string]interface{}, error) {
+	res := make(map[string]interface{}, rv.Len())
+	for _, k := range sortedMapKeys(rv) {
+		key := k.String()
+		v, err := tm.convert(joinPath(path, key), rv.MapIndex(k), depth)
+		if err != nil {
+			return nil, err
+		}
+		res[key] = v
+	}
+
+	return res, nil
+}
+
+// convert converts v that is a value in the map or slice at depth.
+func (tm *toMapper) convert(path string, v reflect.Value, depth int) (interface{}, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, nil
+		}
+
+		if v.Kind() == reflect.Ptr {
+			if tm.keep(v, depth) {
+				break
+			}
+
+			k := visitKey{typ: v.Type().Elem(), addr: v.Pointer()}
+			if tm.visiting[k] {
+				return nil, fmt.Errorf("field %s: cyclic reference is detected", path)
+			}
+			tm.visiting[k] = true
+			defer delete(tm.visiting, k)
+		}
+
+		v = v.Elem()
+	}
+
+	if tm.keep(v, depth) {
+		return util.ToI(reflect.Indirect(v)), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		return tm.fromStruct(path, v, depth+1)
+	case reflect.Map:
+		if v.IsNil() {
+			return nil, nil
+		}
+		if v.Type().Key().Kind() != reflect.String {
+			return util.ToI(v), nil
+		}
+		return tm.fromMap(path, v, depth+1)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil, nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return util.ToI(v), nil
+		}
+
+		res := make([]interface{}, v.Len())
+		for i := range res {
+			e, err := tm.convert(fmt.Sprintf("%s[%d]", path, i), v.Index(i), depth+1)
+			if err != nil {
+				return nil, err
+			}
+			res[i] = e
+		}
+		return res, nil
+	default:
+		return util.ToI(v), nil
+	}
+}
+
+// keep reports whether v at depth is kept as original value without conversion.
+func (tm *toMapper) keep(v reflect.Value, depth int) bool {
+	if tm.maxDepth > 0 && depth >= tm.maxDepth {
+		return true
+	}
+
+	t := v.Type()
+	return t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType)
+}
+
+// joinPath returns the path of the field name in the struct at prefix.
+func joinPath(prefix string, name string) string {
+	if prefix == "" {
+		return name
+	}
+
+	return prefix + defaultSep + name
+}
@@ -0,0 +1,304 @@
+package structil_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	. "github.com/goldeneggg/structil"
+)
+
+type (
+	ToMapTestStruct struct {
+		ToMapTestEmbedded
+		Name     string               `json:"name"`
+		Count    int                  `json:"count,omitempty"`
+		Child    *ToMapTestChild      `json:"child"`
+		Children []ToMapTestChild     `json:"children"`
+		Labels   map[string]int       `json:"labels"`
+		ByID     map[int]string       `json:"by_id"`
+		Intf     interface{}          `json:"intf"`
+		Bytes    []byte               `json:"bytes"`
+		Time     time.Time            `json:"time"`
+		Ignored  string               `json:"-"`
+		Nilptr   *ToMapTestChild      `json:"nilptr"`
+		Array    [2]*ToMapTestChild   `json:"array"`
+		Nested   map[string][]float64 `json:"nested"`
+		private  string
+	}
+
+	ToMapTestEmbedded struct {
+		ID int `json:"id"`
+	}
+
+	ToMapTestChild struct {
+		Value string `json:"value"`
+		Next  *ToMapTestChild
+	}
+
+	ToMapTestNode struct {
+		Meta    ToMapTestEmbedded
+		MetaRef *ToMapTestEmbedded
+	}
+)
+
+var toMapTestTime = time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func newToMapTestStruct() *ToMapTestStruct {
+	return &ToMapTestStruct{
+		ToMapTestEmbedded: ToMapTestEmbedded{ID: 1},
+		Name:              "name",
+		Child:             &ToMapTestChild{Value: "c"},
+		Children:          []ToMapTestChild{{Value: "c0"}, {Value: "c1", Next: &ToMapTestChild{Value: "c1n"}}},
+		Labels:            map[string]int{"a": 1},
+		ByID:              map[int]string{1: "one"},
+		Intf:              ToMapTestChild{Value: "i"},
+		Bytes:             []byte("b"),
+		Time:              toMapTestTime,
+		Ignored:           "ignored",
+		Array:             [2]*ToMapTestChild{{Value: "a0"}},
+		Nested:            map[string][]float64{"x": {1.5}},
+		private:           "private",
+	}
+}
+
+func TestGetterToMap(t *testing.T) {
+	t.Parallel()
+
+	s := newToMapTestStruct()
+	g, err := NewGetter(s)
+	if err != nil {
+		t.Fatalf("NewGetter() unexpected error [%v] occured.", err)
+	}
+
+	got, err := g.ToMap()
+	if err != nil {
+		t.Fatalf("ToMap() unexpected error [%v] occured.", err)
+	}
+
+	want := map[string]interface{}{
+		"ToMapTestEmbedded": s.ToMapTestEmbedded,
+		"Name":              "name",
+		"Count":             0,
+		"Child":             *s.Child,
+		"Children":          s.Children,
+		"Labels":            s.Labels,
+		"ByID":              s.ByID,
+		"Intf":              s.Intf,
+		"Bytes":             s.Bytes,
+		"Time":              toMapTestTime,
+		"Ignored":           "ignored",
+		"Nilptr":            nil,
+		"Array":             s.Array,
+		"Nested":            s.Nested,
+	}
+	if d := cmp.Diff(got, want); d != "" {
+		t.Errorf("ToMap() unexpected result. (-got +want)\n%s", d)
+	}
+}
+
+func TestGetterToMapRecursive(t *testing.T) {
+	t.Parallel()
+
+	g, err := NewGetter(newToMapTestStruct())
+	if err != nil {
+		t.Fatalf("NewGetter() unexpected error [%v] occured.", err)
+	}
+
+	got, err := g.ToMapRecursive()
+	if err != nil {
+		t.Fatalf("ToMapRecursive() unexpected error [%v] occured.", err)
+	}
+
+	want := map[string]interface{}{
+		"ToMapTestEmbedded": map[string]interface{}{"ID": 1},
+		"Name":              "name",
+		"Count":             0,
+		"Child":             map[string]interface{}{"Value": "c", "Next": nil},
+		"Children": []interface{}{
+			map[string]interface{}{"Value": "c0", "Next": nil},
+			map[string]interface{}{"Value": "c1", "Next": map[string]interface{}{"Value": "c1n", "Next": nil}},
+		},
+		"Labels":  map[string]interface{}{"a": 1},
+		"ByID":    map[int]string{1: "one"},
+		"Intf":    map[string]interface{}{"Value": "i", "Next": nil},
+		"Bytes":   []byte("b"),
+		"Time":    toMapTestTime,
+		"Ignored": "ignored",
+		"Nilptr":  nil,
+		"Array":   []interface{}{map[string]interface{}{"Value": "a0", "Next": nil}, nil},
+		"Nested":  map[string]interface{}{"x": []interface{}{1.5}},
+	}
+	if d := cmp.Diff(got, want); d != "" {
+		t.Errorf("ToMapRecursive() unexpected result. (-got +want)\n%s", d)
+	}
+}
+
+func TestGetterToMapWithOption(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		getOpt *GetterOption
+		opt    *ToMapOption
+		want   map[string]interface{}
+	}{
+		{
+			name: "json tag and omit zero",
+			opt:  &ToMapOption{Tag: "json", OmitZero: true},
+			want: map[string]interface{}{
+				"ToMapTestEmbedded": map[string]interface{}{"id": 1},
+				"name":              "name",
+				"child": map[string]interface{}{
+					"value": "c",
+				},
+				"children": []interface{}{
+					map[string]interface{}{"value": "c0"},
+					map[string]interface{}{"value": "c1", "Next": map[string]interface{}{"value": "c1n"}},
+				},
+				"labels": map[string]interface{}{"a": 1},
+				"by_id":  map[int]string{1: "one"},
+				"intf":   map[string]interface{}{"value": "i"},
+				"bytes":  []byte("b"),
+				"time":   toMapTestTime,
+				"array":  []interface{}{map[string]interface{}{"value": "a0"}, nil},
+				"nested": map[string]interface{}{"x": []interface{}{1.5}},
+			},
+		},
+		{
+			name:   "tag of GetterOption and max depth",
+			getOpt: &GetterOption{Tag: "json", FlattenEmbedded: true},
+			opt:    &ToMapOption{OmitZero: true, MaxDepth: 2},
+			want: map[string]interface{}{
+				"id":   1,
+				"name": "name",
+				"child": map[string]interface{}{
+					"value": "c",
+				},
+				"children": []interface{}{
+					ToMapTestChild{Value: "c0"},
+					ToMapTestChild{Value: "c1", Next: &ToMapTestChild{Value: "c1n"}},
+				},
+				"labels": map[string]interface{}{"a": 1},
+				"by_id":  map[int]string{1: "one"},
+				"intf":   map[string]interface{}{"value": "i"},
+				"bytes":  []byte("b"),
+				"time":   toMapTestTime,
+				"array":  []interface{}{ToMapTestChild{Value: "a0"}, nil},
+				"nested": map[string]interface{}{"x": []float64{1.5}},
+			},
+		},
+		{
+			name:   "unexported",
+			getOpt: &GetterOption{Unexported: true},
+			opt:    &ToMapOption{OmitZero: true, MaxDepth: 1},
+			want: map[string]interface{}{
+				"ToMapTestEmbedded": ToMapTestEmbedded{ID: 1},
+				"Name":              "name",
+				"Child":             ToMapTestChild{Value: "c"},
+				"Children":          newToMapTestStruct().Children,
+				"Labels":            map[string]int{"a": 1},
+				"ByID":              map[int]string{1: "one"},
+				"Intf":              ToMapTestChild{Value: "i"},
+				"Bytes":             []byte("b"),
+				"Time":              toMapTestTime,
+				"Ignored":           "ignored",
+				"Array":             newToMapTestStruct().Array,
+				"Nested":            map[string][]float64{"x": {1.5}},
+				"private":           "private",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			g, err := NewGetterWithOption(newToMapTestStruct(), tt.getOpt)
+			if err != nil {
+				t.Fatalf("NewGetterWithOption() unexpected error [%v] occured.", err)
+			}
+
+			got, err := g.ToMapWithOption(tt.opt)
+			if err != nil {
+				t.Fatalf("ToMapWithOption() unexpected error [%v] occured.", err)
+			}
+			if d := cmp.Diff(got, tt.want); d != "" {
+				t.Errorf("ToMapWithOption() unexpected result. (-got +want)\n%s", d)
+			}
+		})
+	}
+}
+
+func TestGetterToMapRecursiveWithMap(t *testing.T) {
+	t.Parallel()
+
+	g, err := NewGetter(map[string]interface{}{
+		"a": &ToMapTestChild{Value: "a"},
+		"b": []interface{}{map[string]string{"c": "d"}},
+	})
+	if err != nil {
+		t.Fatalf("NewGetter() unexpected error [%v] occured.", err)
+	}
+
+	got, err := g.ToMapRecursive()
+	if err != nil {
+		t.Fatalf("ToMapRecursive() unexpected error [%v] occured.", err)
+	}
+
+	want := map[string]interface{}{
+		"a": map[string]interface{}{"Value": "a", "Next": nil},
+		"b": []interface{}{map[string]interface{}{"c": "d"}},
+	}
+	if d := cmp.Diff(got, want); d != "" {
+		t.Errorf("ToMapRecursive() unexpected result. (-got +want)\n%s", d)
+	}
+}
+
+func TestGetterToMapRecursiveCyclic(t *testing.T) {
+	t.Parallel()
+
+	c := &ToMapTestChild{Value: "c"}
+	c.Next = &ToMapTestChild{Value: "n", Next: c}
+
+	g, err := NewGetter(c)
+	if err != nil {
+		t.Fatalf("NewGetter() unexpected error [%v] occured.", err)
+	}
+
+	_, err = g.ToMapRecursive()
+	if err == nil || !strings.Contains(err.Error(), "field Next.Next: cyclic reference") {
+		t.Errorf("ToMapRecursive() unexpected error: %v", err)
+	}
+
+	// the same pointer in sibling fields is not cyclic
+	g, err = NewGetter(&ToMapTestStruct{Child: c.Next, Nilptr: c.Next})
+	if err != nil {
+		t.Fatalf("NewGetter() unexpected error [%v] occured.", err)
+	}
+	if _, err = g.ToMapWithOption(&ToMapOption{MaxDepth: 3}); err != nil {
+		t.Errorf("ToMapWithOption() unexpected error [%v] occured.", err)
+	}
+
+	// the pointer to the field that has the same address as the root is not cyclic
+	n := &ToMapTestNode{Meta: ToMapTestEmbedded{ID: 1}}
+	n.MetaRef = &n.Meta
+	g, err = NewGetter(n)
+	if err != nil {
+		t.Fatalf("NewGetter() unexpected error [%v] occured.", err)
+	}
+	got, err := g.ToMapRecursive()
+	if err != nil {
+		t.Fatalf("ToMapRecursive() unexpected error [%v] occured.", err)
+	}
+	want := map[string]interface{}{
+		"Meta":    map[string]interface{}{"ID": 1},
+		"MetaRef": map[string]interface{}{"ID": 1},
+	}
+	if d := cmp.Diff(got, want); d != "" {
+		t.Errorf("ToMapRecursive() unexpected map. (-got +want)\n%s", d)
+	}
+}
@@ -0,0 +1,80 @@
+package structil
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// TransformFunc is the function that transforms a value looked up by Finder.
+type TransformFunc func(v interface{}) (interface{}, error)
+
+var (
+	transformsMu sync.RWMutex
+	transforms   = map[string]TransformFunc{
+		"upper":  stringTransform(strings.ToUpper),
+		"lower":  stringTransform(strings.ToLower),
+		"trim":   stringTransform(strings.TrimSpace),
+		"string": transformString,
+	}
+)
+
+// RegisterTransform registers fn as the transform named name.
+// Registered transforms can be used by names in configuration files of FinderKeys.
+// Built-in transforms are "upper", "lower", "trim" and "string".
+func RegisterTransform(name string, fn TransformFunc) error {
+	if name == "" || fn == nil {
+		return fmt.Errorf("transform name [%s] or func is invalid", name)
+	}
+
+	transformsMu.Lock()
+	defer transformsMu.Unlock()
+
+	if _, ok := transforms[name]; ok {
+		return fmt.Errorf("transform %s is already registered", name)
+	}
+	transforms[name] = fn
+
+	return nil
+}
+
+func lookupTransform(name string) (TransformFunc, bool) {
+	transformsMu.RLock()
+	defer transformsMu.RUnlock()
+
+	fn, ok := transforms[name]
+	return fn, ok
+}
+
+// stringTransform returns a TransformFunc that applies f to string values.
+// nil is kept as it is.
+func stringTransform(f func(string) string) TransformFunc {
+	return func(v interface{}) (interface{}, error) {
+		rv := indirect(reflect.ValueOf(v))
+		if !rv.IsValid() {
+			return nil, nil
+		}
+		if rv.Kind() != reflect.String {
+			return nil, fmt.Errorf("%T is not string", v)
+		}
+
+		return f(rv.String()), nil
+	}
+}
+
+// transformString converts v into a string. nil is kept as it is.
+func transformString(v interface{}) (interface{}, error) {
+	rv := indirect(reflect.ValueOf(v))
+	if !rv.IsValid() {
+		return nil, nil
+	}
+	if s, ok := v.(fmt.Stringer); ok {
+		return s.String(), nil
+	}
+	if b, ok := rv.Interface().([]byte); ok {
+		return string(b), nil
+	}
+
+	return fmt.Sprint(rv.Interface()), nil
+}
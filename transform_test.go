@@ -0,0 +1,127 @@
+package structil_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	. "github.com/goldeneggg/structil"
+)
+
+type transformTestStringer struct {
+	v int
+}
+
+func (s transformTestStringer) String() string {
+	return fmt.Sprintf("stringer %d", s.v)
+}
+
+func TestRegisterTransform(t *testing.T) {
+	t.Parallel()
+
+	err := RegisterTransform("test_reverse", func(v interface{}) (interface{}, error) {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("%T is not string", v)
+		}
+		r := []rune(s)
+		for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+			r[i], r[j] = r[j], r[i]
+		}
+		return string(r), nil
+	})
+	if err != nil {
+		t.Fatalf("RegisterTransform() unexpected error [%v] occured.", err)
+	}
+
+	if err := RegisterTransform("test_reverse", func(v interface{}) (interface{}, error) { return v, nil }); err == nil {
+		t.Errorf("RegisterTransform() error did not occur for duplicated name")
+	}
+	if err := RegisterTransform("upper", func(v interface{}) (interface{}, error) { return v, nil }); err == nil {
+		t.Errorf("RegisterTransform() error did not occur for built-in name")
+	}
+	if err := RegisterTransform("", func(v interface{}) (interface{}, error) { return v, nil }); err == nil {
+		t.Errorf("RegisterTransform() error did not occur for empty name")
+	}
+	if err := RegisterTransform("test_nil", nil); err == nil {
+		t.Errorf("RegisterTransform() error did not occur for nil func")
+	}
+
+	fks, err := NewFinderKeysFromBytes([]byte("Keys:\n  - String:\n      transform: test_reverse\n"), "yaml")
+	if err != nil {
+		t.Fatalf("NewFinderKeysFromBytes() unexpected error [%v] occured.", err)
+	}
+
+	f, err := NewFinder(newFinderTestStructPtr())
+	if err != nil {
+		t.Fatalf("NewFinder() unexpected error [%v] occured.", err)
+	}
+
+	got, err := f.FromKeys(fks).ToMap()
+	if err != nil {
+		t.Fatalf("ToMap() unexpected error [%v] occured.", err)
+	}
+	if d := cmp.Diff(got, map[string]interface{}{"String": "eman tset"}); d != "" {
+		t.Errorf("ToMap() unexpected result. (-got +want)\n%s", d)
+	}
+}
+
+func TestBuiltinTransforms(t *testing.T) {
+	t.Parallel()
+
+	s := " Abc "
+	var nilptr *string
+
+	tests := []struct {
+		name      string
+		transform string
+		value     interface{}
+		want      interface{}
+		wantError bool
+	}{
+		{name: "upper", transform: "upper", value: s, want: " ABC "},
+		{name: "upper with pointer", transform: "upper", value: &s, want: " ABC "},
+		{name: "upper with nil", transform: "upper", value: nilptr, want: nil},
+		{name: "upper with int", transform: "upper", value: 1, wantError: true},
+		{name: "lower", transform: "lower", value: s, want: " abc "},
+		{name: "trim", transform: "trim", value: s, want: "Abc"},
+		{name: "string with int", transform: "string", value: -1, want: "-1"},
+		{name: "string with bytes", transform: "string", value: []byte("b"), want: "b"},
+		{name: "string with Stringer", transform: "string", value: transformTestStringer{v: 1}, want: "stringer 1"},
+		{name: "string with nil", transform: "string", value: nil, want: nil},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fks, err := NewFinderKeysFromBytes([]byte(fmt.Sprintf("Keys:\n  - V:\n      transform: %s\n", tt.transform)), "yaml")
+			if err != nil {
+				t.Fatalf("NewFinderKeysFromBytes() unexpected error [%v] occured.", err)
+			}
+
+			f, err := NewFinder(map[string]interface{}{"V": tt.value})
+			if err != nil {
+				t.Fatalf("NewFinder() unexpected error [%v] occured.", err)
+			}
+
+			got, err := f.FromKeys(fks).ToMap()
+			if tt.wantError {
+				if err == nil || !strings.Contains(err.Error(), "transform "+tt.transform) {
+					t.Errorf("ToMap() unexpected error: %v", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ToMap() unexpected error [%v] occured.", err)
+			}
+			if d := cmp.Diff(got["V"], tt.want); d != "" {
+				t.Errorf("ToMap() unexpected result. (-got +want)\n%s", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package structil
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// typeInfo has field informations of a struct type.
+// typeInfo is shared by all Getters for the same struct type, so this must not be modified after created.
+type typeInfo struct {
+	names     []string              // top level field names
+	flatNames []string              // field names that embedded structs are replaced with their promoted fields
+	fields    map[string]*fieldInfo // fields looked up by name including promoted fields
+	list      []*fieldInfo          // fields in the order of index sequence
+}
+
+// fieldInfo has informations of a field reachable from a struct type.
+type fieldInfo struct {
+	name     string
+	sf       reflect.StructField
+	index    []int // index sequence for fieldByIndex
+	tagged   bool  // whether the name is given by the struct tag
+	promotes bool  // whether the field is an embedded struct that promotes its fields
+}
+
+// typeKey is the key of typeInfos.
+type typeKey struct {
+	typ reflect.Type
+	tag string
+}
+
+// typeInfos is the process-wide cache of typeInfo keyed by reflect.Type and tag.
+var typeInfos sync.Map
+
+// typeInfoOf returns the cached typeInfo of struct type t.
+// If tag is not empty, fields are named by the tag.
+func typeInfoOf(t reflect.Type, tag string) *typeInfo {
+	key := typeKey{typ: t, tag: tag}
+	if ti, ok := typeInfos.Load(key); ok {
+		return ti.(*typeInfo)
+	}
+
+	ti, _ := typeInfos.LoadOrStore(key, newTypeInfo(t, tag))
+	return ti.(*typeInfo)
+}
+
+// fieldName returns the name of sf used for lookups with tag.
+// The name in the tag is used, and the field name is used if tag is empty or sf does not have the tag.
+// 2nd return value reports whether the name is given by the tag.
+// 3rd return value will be false if sf is ignored with "-" tag.
+func fieldName(sf reflect.StructField, tag string) (string, bool, bool) {
+	if tag == "" {
+		return sf.Name, false, true
+	}
+
+	tv, ok := sf.Tag.Lookup(tag)
+	if !ok {
+		return sf.Name, false, true
+	}
+	if tv == "-" {
+		return "", false, false
+	}
+
+	// remove tag options. e.g. `json:"name,omitempty"`
+	if i := strings.Index(tv, ","); i >= 0 {
+		tv = tv[:i]
+	}
+	if tv == "" {
+		return sf.Name, false, true
+	}
+
+	return tv, true, true
+}
+
+// embedded is a struct type to be scanned with the index sequence from the top level struct.
+type embedded struct {
+	typ   reflect.Type
+	index []int
+}
+
+// newTypeInfo scans all fields of struct type t including promoted fields.
+// Promoted fields follow the Go selector rules:
+// the shallowest field wins, and fields with the same name at the same depth hide each other
+// unless only one of them is named by the tag.
+// Embedded structs named by the tag do not promote their fields as well as encoding/json.
+func newTypeInfo(t reflect.Type, tag string) *typeInfo {
+	ti := &typeInfo{
+		names:  make([]string, 0, t.NumField()),
+		fields: map[string]*fieldInfo{},
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if name, _, ok := fieldName(t.Field(i), tag); ok {
+			ti.names = append(ti.names, name)
+		}
+	}
+
+	decided := map[string]bool{}
+	visited := map[reflect.Type]bool{}
+	current := []embedded{{typ: t}}
+
+	for len(current) > 0 {
+		var next []embedded
+		found := map[string][]*fieldInfo{}
+
+		// the same type embedded twice at the same depth makes its fields ambiguous,
+		// so types are marked as visited after all types at the depth are scanned
+		for _, e := range current {
+			if visited[e.typ] {
+				continue
+			}
+
+			for i := 0; i < e.typ.NumField(); i++ {
+				sf := e.typ.Field(i)
+				name, tagged, ok := fieldName(sf, tag)
+				if !ok {
+					continue
+				}
+
+				index := make([]int, len(e.index)+1)
+				copy(index, e.index)
+				index[len(e.index)] = i
+
+				fi := &fieldInfo{name: name, sf: sf, index: index, tagged: tagged}
+				found[name] = append(found[name], fi)
+
+				if sf.Anonymous && !tagged {
+					ft := sf.Type
+					if ft.Kind() == reflect.Ptr {
+						ft = ft.Elem()
+					}
+					if ft.Kind() == reflect.Struct {
+						fi.promotes = true
+						next = append(next, embedded{typ: ft, index: index})
+					}
+				}
+			}
+		}
+
+		for _, e := range current {
+			visited[e.typ] = true
+		}
+
+		for name, fis := range found {
+			if decided[name] {
+				continue
+			}
+			decided[name] = true
+
+			if fi := dominantField(fis); fi != nil {
+				ti.fields[name] = fi
+				ti.list = append(ti.list, fi)
+			}
+		}
+
+		current = next
+	}
+
+	sort.Slice(ti.list, func(i, j int) bool {
+		return lessIndex(ti.list[i].index, ti.list[j].index)
+	})
+
+	ti.flatNames = make([]string, 0, len(ti.list))
+	for _, fi := range ti.list {
+		if !fi.promotes {
+			ti.flatNames = append(ti.flatNames, fi.name)
+		}
+	}
+
+	return ti
+}
+
+// lessIndex reports whether index sequence x is ordered before y in the struct declaration.
+func lessIndex(x []int, y []int) bool {
+	for i := 0; i < len(x) && i < len(y); i++ {
+		if x[i] != y[i] {
+			return x[i] < y[i]
+		}
+	}
+
+	return len(x) < len(y)
+}
+
+// dominantField returns the accessible field in fis that have the same name at the same depth.
+// nil is returned if the fields are ambiguous.
+func dominantField(fis []*fieldInfo) *fieldInfo {
+	if len(fis) == 1 {
+		return fis[0]
+	}
+
+	var res *fieldInfo
+	for _, fi := range fis {
+		if fi.tagged {
+			if res != nil {
+				return nil
+			}
+			res = fi
+		}
+	}
+
+	return res
+}
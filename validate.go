@@ -0,0 +1,175 @@
+package structil
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var interfaceType = reflect.TypeOf((*interface{})(nil)).Elem()
+
+// Validate checks that the fields of all keys exist in type t without any values,
+// and returns the types of the fields by paths of keys.
+// t must be a struct, struct pointer or map type that has string keys.
+// e.g. reflect.TypeOf(Person{}), reflect.TypeOf(ds.NewInterface()) for a DynamicStruct ds.
+//
+// Keys that have braces are expanded into paths as well as Query.
+// The types of fields looked up by accessors are element types, and pointers are dereferenced as well as Finder.
+// Paths through interfaces and the wildcard name "*" can not be checked, so the types of them are the interface types.
+// Fields are looked up by names of struct fields, not by struct tags.
+// Optional keys whose fields do not exist are not errors and are not included in the result.
+//
+// The returned error is *FinderError that holds all errors by paths.
+func (fks *FinderKeys) Validate(t reflect.Type) (map[string]reflect.Type, error) {
+	if t == nil {
+		return nil, fmt.Errorf("type is nil")
+	}
+
+	it := indirectType(t)
+	if it.Kind() != reflect.Struct && (it.Kind() != reflect.Map || it.Key().Kind() != reflect.String) {
+		return nil, fmt.Errorf("type %v is not supported. struct, struct pointer or map that has string keys is required", t)
+	}
+
+	res := map[string]reflect.Type{}
+	eMap := map[string][]error{}
+
+	for _, key := range fks.keys {
+		paths, err := parseQuery(key, defaultSep)
+		if err != nil {
+			eMap[key] = append(eMap[key], err)
+			continue
+		}
+
+		spec := fks.specs[key]
+		for _, path := range paths {
+			path = normalizeNames(path)
+			pk := strings.Join(path, defaultSep)
+
+			ft, err := typeOfPath(it, path)
+			if err != nil {
+				if spec != nil && spec.optional && errors.Is(err, ErrFieldNotFound) {
+					continue
+				}
+				eMap[pk] = append(eMap[pk], err)
+				continue
+			}
+			res[pk] = ft
+		}
+	}
+
+	if len(eMap) > 0 {
+		return nil, newFinderError(eMap)
+	}
+
+	return res, nil
+}
+
+// typeOfPath returns the type of the field looked up by names from type t.
+func typeOfPath(t reflect.Type, names []string) (reflect.Type, error) {
+	for i, name := range names {
+		ft, err := typeOfName(t, name)
+		if err != nil {
+			return nil, fmt.Errorf("Error in name: %s, key: %s. [%w]", name, strings.Join(names[:i+1], defaultSep), err)
+		}
+		t = ft
+	}
+
+	return t, nil
+}
+
+// typeOfName returns the type of the field named name in type t.
+func typeOfName(t reflect.Type, name string) (reflect.Type, error) {
+	t = indirectType(t)
+
+	var ft reflect.Type
+	switch t.Kind() {
+	case reflect.Interface:
+		return t, nil
+	case reflect.Struct:
+		if name == wildcard {
+			return interfaceType, nil
+		}
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("key type %s is not supported", t.Key())
+		}
+		if name == wildcard {
+			return t.Elem(), nil
+		}
+		ft = t.Elem()
+	default:
+		return nil, fmt.Errorf("name %s is not looked up in %v. struct or map is required", name, t)
+	}
+
+	seg, err := parseSegment(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if ft == nil {
+		fi, ok := typeInfoOf(t, "").fields[seg.name]
+		if !ok {
+			return nil, newFieldNotFoundError(seg.name)
+		}
+		ft = fi.sf.Type
+	}
+
+	at := seg.name
+	for _, a := range seg.accessors {
+		ft, err = typeOfAccess(ft, a, at)
+		if err != nil {
+			return nil, err
+		}
+		at += a.String()
+	}
+
+	return ft, nil
+}
+
+// typeOfAccess returns the type of the element of type t indicated by a as well as access.
+// at is used for error messages.
+func typeOfAccess(t reflect.Type, a accessor, at string) (reflect.Type, error) {
+	t = indirectType(t)
+
+	switch t.Kind() {
+	case reflect.Interface:
+		return t, nil
+	case reflect.Slice, reflect.Array:
+		if !a.isWildcard() {
+			if a.quoted {
+				return nil, fmt.Errorf("%s is %v. index must be an integer: %q", at, t.Kind(), a.text)
+			}
+			idx, err := strconv.Atoi(a.text)
+			if err != nil {
+				return nil, fmt.Errorf("%s is %v. index must be an integer: %s", at, t.Kind(), a.text)
+			}
+			if idx < 0 {
+				return nil, fmt.Errorf("index %d is out of range of %s", idx, at)
+			}
+			if t.Kind() == reflect.Array && idx >= t.Len() {
+				return nil, newNotFoundError("index %d is out of range of %s (len %d)", idx, at, t.Len())
+			}
+		}
+	case reflect.Map:
+		if !a.isWildcard() {
+			if _, err := mapKey(a.text, t.Key()); err != nil {
+				return nil, fmt.Errorf("%s has invalid key %q: %v", at, a.text, err)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%s is not slice, array or map: %v", at, t.Kind())
+	}
+
+	return indirectType(t.Elem()), nil
+}
+
+// indirectType returns the type that t points to if t is a pointer type.
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t
+}
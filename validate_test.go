@@ -0,0 +1,148 @@
+package structil_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	. "github.com/goldeneggg/structil"
+)
+
+func TestFinderKeysValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		conf      string
+		typ       reflect.Type
+		wantTypes map[string]reflect.Type
+		wantPaths []string
+	}{
+		{
+			name: "with struct type",
+			conf: `
+Keys:
+  - Int64
+  - Stringptr
+  - FinderTestStruct2Ptr:
+    - String
+    - FinderTestStruct3:
+      - Int
+  - NonExist:
+      optional: true
+`,
+			typ: reflect.TypeOf(FinderTestStruct{}),
+			wantTypes: map[string]reflect.Type{
+				"Int64":                       reflect.TypeOf(int64(0)),
+				"Stringptr":                   reflect.TypeOf((*string)(nil)),
+				"FinderTestStruct2Ptr.String": reflect.TypeOf(""),
+				"FinderTestStruct2Ptr.FinderTestStruct3.Int": reflect.TypeOf(0),
+			},
+		},
+		{
+			name: "with accessors, wildcards and braces",
+			conf: `
+Keys:
+  - Stringslice[1]
+  - Stringslice.*
+  - Map["k1"].Any
+  - FinderTestStruct4PtrSlice[0]
+  - FinderTestStruct4Slice[*].{String,String2}
+`,
+			typ: reflect.TypeOf(&FinderTestStruct{}),
+			wantTypes: map[string]reflect.Type{
+				"Stringslice[1]":                    reflect.TypeOf(""),
+				"Stringslice[*]":                    reflect.TypeOf(""),
+				`Map["k1"].Any`:                     reflect.TypeOf((*interface{})(nil)).Elem(),
+				"FinderTestStruct4PtrSlice[0]":      reflect.TypeOf(FinderTestStruct4{}),
+				"FinderTestStruct4Slice[*].String":  reflect.TypeOf(""),
+				"FinderTestStruct4Slice[*].String2": reflect.TypeOf(""),
+			},
+		},
+		{
+			name: "with map type",
+			conf: `
+Keys:
+  - a:
+    - b
+`,
+			typ: reflect.TypeOf(map[string]map[string]int{}),
+			wantTypes: map[string]reflect.Type{
+				"a.b": reflect.TypeOf(0),
+			},
+		},
+		{
+			name: "with invalid keys",
+			conf: `
+Keys:
+  - Strnig
+  - Int64.Value
+  - FinderTestStruct2:
+    - NonExist
+    - FinderTestStruct3:
+      - Int
+  - Stringslice["a"]
+  - Map[1]
+  - A..B
+  - String:
+      optional: true
+`,
+			typ: reflect.TypeOf(FinderTestStruct{}),
+			wantPaths: []string{
+				"A..B",
+				"FinderTestStruct2.NonExist",
+				"Int64.Value",
+				`Stringslice["a"]`,
+				"Strnig",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fks, err := NewFinderKeysFromBytes([]byte(tt.conf), "yaml")
+			if err != nil {
+				t.Fatalf("NewFinderKeysFromBytes() unexpected error [%v] occured.", err)
+			}
+
+			got, err := fks.Validate(tt.typ)
+			if tt.wantPaths != nil {
+				var fe *FinderError
+				if !errors.As(err, &fe) {
+					t.Fatalf("Validate() unexpected error: %v", err)
+				}
+				if d := cmp.Diff(fe.Paths(), tt.wantPaths); d != "" {
+					t.Errorf("Validate() unexpected error paths. (-got +want)\n%s\nerror: %v", d, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Validate() unexpected error [%v] occured.", err)
+			}
+			if d := cmp.Diff(got, tt.wantTypes, cmp.Comparer(func(x, y reflect.Type) bool { return x == y })); d != "" {
+				t.Errorf("Validate() unexpected result. (-got +want)\n%s", d)
+			}
+		})
+	}
+}
+
+func TestFinderKeysValidateWithUnsupportedType(t *testing.T) {
+	t.Parallel()
+
+	fks, err := NewFinderKeysFromBytes([]byte("Keys:\n  - String\n"), "yaml")
+	if err != nil {
+		t.Fatalf("NewFinderKeysFromBytes() unexpected error [%v] occured.", err)
+	}
+
+	for _, typ := range []reflect.Type{nil, reflect.TypeOf(1), reflect.TypeOf(map[int]string{})} {
+		if _, err := fks.Validate(typ); err == nil {
+			t.Errorf("Validate() error did not occur for %v", typ)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package structil
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+var (
+	// ErrSkipField is used as a return value from WalkFunc to indicate that nested fields of the field are not visited.
+	// It is not returned as an error by any function.
+	ErrSkipField = errors.New("skip this field")
+
+	// ErrStopWalk is used as a return value from WalkFunc to indicate that no more fields are visited.
+	// It is not returned as an error by any function.
+	ErrStopWalk = errors.New("stop walking")
+)
+
+// WalkFunc is the type of the function called for each field visited by Walk and WalkRecursive.
+//
+// path is the path of the field that can be used with Finder. e.g. `Company.Groups[0].Name`, `Labels["env"]`.
+// sf is the struct field. It is the zero StructField for elements of slice, array and map, and for values of the map that Getter wraps.
+// v is the value of the field that is not indirected.
+//
+// If the function returns ErrSkipField, nested fields of the field are not visited.
+// If the function returns ErrStopWalk, walking stops and nil is returned.
+// If the function returns the other error, walking stops and the error is returned.
+type WalkFunc func(path string, sf reflect.StructField, v reflect.Value) error
+
+// Walk calls fn for each field of the original struct in the order of Names.
+// Nested fields are not visited.
+func (g *Getter) Walk(fn WalkFunc) error {
+	return ignoreStop(g.walk("", fn, nil))
+}
+
+// WalkRecursive calls fn for each field of the original struct in the order of Names,
+// and visits nested fields of structs, slices, arrays and maps recursively.
+// Pointers and interfaces are followed, and map elements are visited in the order of sorted keys.
+// Elements of []byte are not visited, and pointers that refer to a visiting struct are not followed to avoid infinite loop.
+func (g *Getter) WalkRecursive(fn WalkFunc) error {
+	visiting := map[visitKey]bool{}
+	if g.rv.CanAddr() {
+		visiting[visitKey{typ: g.rv.Type(), addr: g.rv.UnsafeAddr()}] = true
+	}
+
+	return ignoreStop(g.walk("", fn, visiting))
+}
+
+// visitKey identifies a value on the way to the current field.
+// The type is required because a struct and its first field have the same address.
+type visitKey struct {
+	typ  reflect.Type
+	addr uintptr
+}
+
+func ignoreStop(err error) error {
+	if err == ErrStopWalk {
+		return nil
+	}
+	return err
+}
+
+// walk visits fields of g. Nested fields are visited if visiting is not nil.
+// visiting holds values on the way to the current field to detect cycles.
+func (g *Getter) walk(prefix string, fn WalkFunc, visiting map[visitKey]bool) error {
+	for _, name := range g.Names() {
+		var sf reflect.StructField
+		if g.ti != nil {
+			fi, ok := g.ti.fields[name]
+			if !ok {
+				// ambiguous names
+				continue
+			}
+			sf = fi.sf
+		}
+
+		path := name
+		if prefix != "" {
+			path = prefix + defaultSep + name
+		}
+
+		if err := g.visit(path, sf, g.field(name).raw, fn, visiting); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (g *Getter) visit(path string, sf reflect.StructField, v reflect.Value, fn WalkFunc, visiting map[visitKey]bool) error {
+	err := fn(path, sf, v)
+	if err == ErrSkipField {
+		return nil
+	}
+	if err != nil || visiting == nil {
+		return err
+	}
+
+	return g.walkNested(path, v, fn, visiting)
+}
+
+// walkNested visits nested fields of v.
+func (g *Getter) walkNested(path string, v reflect.Value, fn WalkFunc, visiting map[visitKey]bool) error {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return nil
+		}
+
+		if v.Kind() == reflect.Ptr {
+			k := visitKey{typ: v.Type().Elem(), addr: v.Pointer()}
+			if visiting[k] {
+				return nil
+			}
+			visiting[k] = true
+			defer delete(visiting, k)
+		}
+
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		cg, err := g.newChild(v)
+		if err != nil {
+			return err
+		}
+		return cg.walk(path, fn, visiting)
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			a := accessor{text: strconv.Itoa(i)}
+			if err := g.visit(path+a.String(), reflect.StructField{}, v.Index(i), fn, visiting); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		for _, k := range sortedMapKeys(v) {
+			a := accessor{text: fmt.Sprint(k)}
+			if k.Kind() == reflect.String {
+				a = accessor{text: k.String(), quoted: true}
+			}
+			if err := g.visit(path+a.String(), reflect.StructField{}, v.MapIndex(k), fn, visiting); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,219 @@
+package structil_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	. "github.com/goldeneggg/structil"
+)
+
+type (
+	WalkTestStruct struct {
+		Name     string
+		Bytes    []byte
+		Child    *WalkTestStruct
+		Children []WalkTestChild
+		Labels   map[string]int
+		Intf     interface{}
+		private  int
+	}
+
+	WalkTestChild struct {
+		ID int
+	}
+
+	WalkTestNode struct {
+		Meta    WalkTestChild
+		MetaRef *WalkTestChild
+	}
+)
+
+func newWalkTestStruct() *WalkTestStruct {
+	ws := &WalkTestStruct{
+		Name:     "parent",
+		Bytes:    []byte("ab"),
+		Children: []WalkTestChild{{ID: 1}, {ID: 2}},
+		Labels:   map[string]int{"b": 2, "a": 1},
+		Intf:     WalkTestChild{ID: 3},
+	}
+	ws.Child = &WalkTestStruct{Name: "child", Child: ws}
+
+	return ws
+}
+
+func TestWalk(t *testing.T) {
+	t.Parallel()
+
+	g, err := NewGetter(newWalkTestStruct())
+	if err != nil {
+		t.Fatalf("NewGetter() unexpected error [%v] occured.", err)
+	}
+
+	var paths []string
+	err = g.Walk(func(path string, sf reflect.StructField, v reflect.Value) error {
+		if sf.Name != path {
+			t.Errorf("unexpected StructField %s for path %s", sf.Name, path)
+		}
+		paths = append(paths, path)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Walk() unexpected error [%v] occured.", err)
+	}
+
+	want := []string{"Name", "Bytes", "Child", "Children", "Labels", "Intf", "private"}
+	if d := cmp.Diff(paths, want); d != "" {
+		t.Errorf("Walk() unexpected paths. (-got +want)\n%s", d)
+	}
+}
+
+func TestWalkRecursive(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		fn      func(path string) error
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "visit all",
+			fn:   func(path string) error { return nil },
+			want: []string{
+				"Name", "Bytes",
+				"Child", "Child.Name", "Child.Bytes", "Child.Child", "Child.Children", "Child.Labels", "Child.Intf", "Child.private",
+				"Children", "Children[0]", "Children[0].ID", "Children[1]", "Children[1].ID",
+				"Labels", `Labels["a"]`, `Labels["b"]`,
+				"Intf", "Intf.ID",
+				"private",
+			},
+		},
+		{
+			name: "skip nested fields",
+			fn: func(path string) error {
+				if path == "Child" || path == "Children" {
+					return ErrSkipField
+				}
+				return nil
+			},
+			want: []string{"Name", "Bytes", "Child", "Children", "Labels", `Labels["a"]`, `Labels["b"]`, "Intf", "Intf.ID", "private"},
+		},
+		{
+			name: "stop walking",
+			fn: func(path string) error {
+				if path == "Children[0]" {
+					return ErrStopWalk
+				}
+				return nil
+			},
+			want: []string{
+				"Name", "Bytes",
+				"Child", "Child.Name", "Child.Bytes", "Child.Child", "Child.Children", "Child.Labels", "Child.Intf", "Child.private",
+				"Children", "Children[0]",
+			},
+		},
+		{
+			name: "abort with error",
+			fn: func(path string) error {
+				if strings.HasPrefix(path, "Labels[") {
+					return errors.New("abort")
+				}
+				return nil
+			},
+			want: []string{
+				"Name", "Bytes",
+				"Child", "Child.Name", "Child.Bytes", "Child.Child", "Child.Children", "Child.Labels", "Child.Intf", "Child.private",
+				"Children", "Children[0]", "Children[0].ID", "Children[1]", "Children[1].ID",
+				"Labels", `Labels["a"]`,
+			},
+			wantErr: errors.New("abort"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			g, err := NewGetter(newWalkTestStruct())
+			if err != nil {
+				t.Fatalf("NewGetter() unexpected error [%v] occured.", err)
+			}
+
+			var paths []string
+			err = g.WalkRecursive(func(path string, sf reflect.StructField, v reflect.Value) error {
+				paths = append(paths, path)
+				return tt.fn(path)
+			})
+
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("WalkRecursive() unexpected error [%v] occured.", err)
+				}
+			} else if err == nil || err.Error() != tt.wantErr.Error() {
+				t.Errorf("WalkRecursive() unexpected error. got: %v, want: %v", err, tt.wantErr)
+			}
+
+			if d := cmp.Diff(paths, tt.want); d != "" {
+				t.Errorf("WalkRecursive() unexpected paths. (-got +want)\n%s", d)
+			}
+		})
+	}
+}
+
+func TestWalkRecursiveWithMap(t *testing.T) {
+	t.Parallel()
+
+	g, err := NewGetter(map[string]interface{}{
+		"b": []interface{}{map[string]interface{}{"c": 1}},
+		"a": WalkTestChild{ID: 1},
+	})
+	if err != nil {
+		t.Fatalf("NewGetter() unexpected error [%v] occured.", err)
+	}
+
+	var paths []string
+	err = g.WalkRecursive(func(path string, sf reflect.StructField, v reflect.Value) error {
+		paths = append(paths, path)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WalkRecursive() unexpected error [%v] occured.", err)
+	}
+
+	want := []string{"a", "a.ID", "b", "b[0]", `b[0]["c"]`}
+	if d := cmp.Diff(paths, want); d != "" {
+		t.Errorf("WalkRecursive() unexpected paths. (-got +want)\n%s", d)
+	}
+}
+
+func TestWalkRecursiveWithPointerToField(t *testing.T) {
+	t.Parallel()
+
+	// MetaRef has the same address as the root, but it is not a cycle
+	n := &WalkTestNode{Meta: WalkTestChild{ID: 1}}
+	n.MetaRef = &n.Meta
+
+	g, err := NewGetter(n)
+	if err != nil {
+		t.Fatalf("NewGetter() unexpected error [%v] occured.", err)
+	}
+
+	var paths []string
+	err = g.WalkRecursive(func(path string, sf reflect.StructField, v reflect.Value) error {
+		paths = append(paths, path)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WalkRecursive() unexpected error [%v] occured.", err)
+	}
+
+	want := []string{"Meta", "Meta.ID", "MetaRef", "MetaRef.ID"}
+	if d := cmp.Diff(paths, want); d != "" {
+		t.Errorf("WalkRecursive() unexpected paths. (-got +want)\n%s", d)
+	}
+}
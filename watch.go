@@ -0,0 +1,162 @@
+package structil
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// FinderKeysWatcher holds FinderKeys loaded from a configuration file and reloads them when the file changes.
+type FinderKeysWatcher struct {
+	path     string
+	realPath string // path that symbolic links are resolved. e.g. ConfigMap of Kubernetes
+	typ      reflect.Type
+	onChange func(fks *FinderKeys, err error)
+	keys     atomic.Value // *FinderKeys
+	watcher  *fsnotify.Watcher
+	done     chan struct{}
+	once     sync.Once
+}
+
+// WatchFinderKeys returns a FinderKeysWatcher that loads FinderKeys from the configuration file at path
+// and reloads them when the file changes. The format of the file is decided by the extension of path.
+//
+// New keys are swapped in atomically, and then onChange is called with them.
+// If the new file is invalid, onChange is called with the error and the previous keys are kept.
+// onChange is called in the goroutine of the watcher, and can be nil. onChange must not call Close.
+// An error is returned if the file is invalid at first.
+//
+// The directory of path is watched, so files replaced by renaming are reloaded as well.
+// Close must be called to stop watching.
+func WatchFinderKeys(path string, onChange func(fks *FinderKeys, err error)) (*FinderKeysWatcher, error) {
+	return WatchFinderKeysWithType(path, nil, onChange)
+}
+
+// WatchFinderKeysWithType returns a FinderKeysWatcher as well as WatchFinderKeys,
+// but keys are validated with FinderKeys.Validate against type t when they are loaded.
+// Keys that are not valid for t are treated as an invalid file.
+func WatchFinderKeysWithType(path string, t reflect.Type, onChange func(fks *FinderKeys, err error)) (*FinderKeysWatcher, error) {
+	w := &FinderKeysWatcher{
+		path:     filepath.Clean(path),
+		typ:      t,
+		onChange: onChange,
+		done:     make(chan struct{}),
+	}
+
+	fks, err := w.load()
+	if err != nil {
+		return nil, err
+	}
+	w.keys.Store(fks)
+	w.realPath, _ = filepath.EvalSymlinks(w.path)
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := watcher.Add(filepath.Dir(w.path)); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+	w.watcher = watcher
+
+	go w.run()
+
+	return w, nil
+}
+
+// Keys returns the current FinderKeys.
+func (w *FinderKeysWatcher) Keys() *FinderKeys {
+	return w.keys.Load().(*FinderKeys)
+}
+
+// Close stops watching. Keys returns the last keys after closed.
+func (w *FinderKeysWatcher) Close() error {
+	var err error
+	w.once.Do(func() {
+		err = w.watcher.Close()
+		<-w.done
+	})
+
+	return err
+}
+
+func (w *FinderKeysWatcher) run() {
+	defer close(w.done)
+
+	for {
+		select {
+		case ev, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			if w.changed(ev) {
+				w.reload()
+			}
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			w.notify(nil, err)
+		}
+	}
+}
+
+// changed reports whether ev changes the file.
+func (w *FinderKeysWatcher) changed(ev fsnotify.Event) bool {
+	if filepath.Clean(ev.Name) == w.path && ev.Op&(fsnotify.Write|fsnotify.Create) != 0 {
+		return true
+	}
+
+	// the target of the symbolic link is changed
+	realPath, _ := filepath.EvalSymlinks(w.path)
+	if realPath != "" && realPath != w.realPath {
+		w.realPath = realPath
+		return true
+	}
+
+	return false
+}
+
+func (w *FinderKeysWatcher) reload() {
+	fks, err := w.load()
+	if err != nil {
+		w.notify(nil, err)
+		return
+	}
+
+	w.keys.Store(fks)
+	w.notify(fks, nil)
+}
+
+func (w *FinderKeysWatcher) load() (*FinderKeys, error) {
+	b, err := ioutil.ReadFile(w.path)
+	if err != nil {
+		return nil, err
+	}
+
+	fks, err := NewFinderKeysFromBytes(b, strings.TrimPrefix(filepath.Ext(w.path), "."))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load keys from %s: %w", w.path, err)
+	}
+
+	if w.typ != nil {
+		if _, err := fks.Validate(w.typ); err != nil {
+			return nil, fmt.Errorf("failed to validate keys from %s: %w", w.path, err)
+		}
+	}
+
+	return fks, nil
+}
+
+func (w *FinderKeysWatcher) notify(fks *FinderKeys, err error) {
+	if w.onChange != nil {
+		w.onChange(fks, err)
+	}
+}
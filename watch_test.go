@@ -0,0 +1,144 @@
+package structil_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	. "github.com/goldeneggg/structil"
+)
+
+type watchTestEvent struct {
+	fks *FinderKeys
+	err error
+}
+
+func writeWatchTestFile(t *testing.T, path string, content string) {
+	t.Helper()
+
+	// write and rename to replace the file atomically
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, []byte(content), 0644); err != nil {
+		t.Fatalf("WriteFile() unexpected error [%v] occured.", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatalf("Rename() unexpected error [%v] occured.", err)
+	}
+}
+
+// waitWatchTestEvent waits for the event that ok returns true.
+func waitWatchTestEvent(t *testing.T, ch <-chan watchTestEvent, ok func(watchTestEvent) bool) {
+	t.Helper()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case ev := <-ch:
+			if ok(ev) {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("expected event did not occur")
+		}
+	}
+}
+
+func TestWatchFinderKeys(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "keys.yml")
+	writeWatchTestFile(t, path, "Keys:\n  - String\n")
+
+	ch := make(chan watchTestEvent, 100)
+	w, err := WatchFinderKeys(path, func(fks *FinderKeys, err error) {
+		ch <- watchTestEvent{fks: fks, err: err}
+	})
+	if err != nil {
+		t.Fatalf("WatchFinderKeys() unexpected error [%v] occured.", err)
+	}
+	defer w.Close()
+
+	if d := cmp.Diff(w.Keys().Keys(), []string{"String"}); d != "" {
+		t.Errorf("Keys() unexpected result. (-got +want)\n%s", d)
+	}
+
+	// renamed file
+	want := []string{"String", "Int64"}
+	writeWatchTestFile(t, path, "Keys:\n  - String\n  - Int64\n")
+	waitWatchTestEvent(t, ch, func(ev watchTestEvent) bool {
+		return ev.fks != nil && cmp.Equal(ev.fks.Keys(), want)
+	})
+	if d := cmp.Diff(w.Keys().Keys(), want); d != "" {
+		t.Errorf("Keys() unexpected result after reloaded. (-got +want)\n%s", d)
+	}
+
+	// invalid file keeps the previous keys
+	writeWatchTestFile(t, path, "Keys:\n  - String\n  - String\n")
+	waitWatchTestEvent(t, ch, func(ev watchTestEvent) bool {
+		return ev.err != nil
+	})
+	if d := cmp.Diff(w.Keys().Keys(), want); d != "" {
+		t.Errorf("Keys() unexpected result after invalid file. (-got +want)\n%s", d)
+	}
+
+	// written file
+	want = []string{"Int64"}
+	if err := ioutil.WriteFile(path, []byte("Keys:\n  - Int64\n"), 0644); err != nil {
+		t.Fatalf("WriteFile() unexpected error [%v] occured.", err)
+	}
+	waitWatchTestEvent(t, ch, func(ev watchTestEvent) bool {
+		return ev.fks != nil && cmp.Equal(ev.fks.Keys(), want)
+	})
+
+	if err := w.Close(); err != nil {
+		t.Errorf("Close() unexpected error [%v] occured.", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Errorf("Close() unexpected error [%v] occured at 2nd time.", err)
+	}
+	if d := cmp.Diff(w.Keys().Keys(), want); d != "" {
+		t.Errorf("Keys() unexpected result after closed. (-got +want)\n%s", d)
+	}
+}
+
+func TestWatchFinderKeysWithType(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	typ := reflect.TypeOf(FinderTestStruct{})
+
+	path := filepath.Join(dir, "invalid.json")
+	writeWatchTestFile(t, path, `{"Keys": ["NonExist"]}`)
+	if _, err := WatchFinderKeysWithType(path, typ, nil); err == nil {
+		t.Errorf("WatchFinderKeysWithType() error did not occur for invalid keys")
+	}
+
+	if _, err := WatchFinderKeys(filepath.Join(dir, "notexist.json"), nil); err == nil {
+		t.Errorf("WatchFinderKeys() error did not occur for the file that does not exist")
+	}
+
+	path = filepath.Join(dir, "keys.json")
+	writeWatchTestFile(t, path, `{"Keys": ["String"]}`)
+
+	ch := make(chan watchTestEvent, 100)
+	w, err := WatchFinderKeysWithType(path, typ, func(fks *FinderKeys, err error) {
+		ch <- watchTestEvent{fks: fks, err: err}
+	})
+	if err != nil {
+		t.Fatalf("WatchFinderKeysWithType() unexpected error [%v] occured.", err)
+	}
+	defer w.Close()
+
+	writeWatchTestFile(t, path, `{"Keys": ["String", "Strnig"]}`)
+	waitWatchTestEvent(t, ch, func(ev watchTestEvent) bool {
+		return ev.err != nil
+	})
+	if d := cmp.Diff(w.Keys().Keys(), []string{"String"}); d != "" {
+		t.Errorf("Keys() unexpected result after invalid keys. (-got +want)\n%s", d)
+	}
+}